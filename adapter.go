@@ -0,0 +1,105 @@
+package hx
+
+import (
+	"net/http"
+)
+
+// ResponseAdapter sets the headers and status code of a response for an HTTP library.
+type ResponseAdapter interface {
+	// SetHeader sets a response header
+	SetHeader(key, value string)
+	// SetStatus sets the status code of the response
+	SetStatus(code int)
+}
+
+// Adapter connects an HTTP library to the request helpers and to ResponseFor.
+//
+// Get returns a request header. To support a new HTTP library, implement Adapter
+// for its request context and use ReadRequest and ResponseFor:
+//
+//	type adapter struct{ ctx *mylib.Context }
+//
+//	func (a adapter) Get(key string) string       { return a.ctx.RequestHeader(key) }
+//	func (a adapter) SetHeader(key, value string) { a.ctx.SetResponseHeader(key, value) }
+//	func (a adapter) SetStatus(code int)          { a.ctx.SetStatus(code) }
+//
+//	if hx.ReadRequest(adapter{ctx}).IsHtmx() {
+//		_, err := hx.ResponseFor(adapter{ctx}, hx.Retarget("#main"))
+//		// handle err
+//	}
+//
+// Adapters may also implement ResponseHeaderAdapter and VaryAdapter.
+// Use hxtest.RunAdapterConformance to check an Adapter.
+type Adapter interface {
+	HeaderGetter
+	ResponseAdapter
+}
+
+// ResponseHeaderAdapter is implemented by adapters that can read the response headers that have already been set.
+//
+// ResponseFor uses it to merge trigger events with the events already set on the response.
+type ResponseHeaderAdapter interface {
+	ResponseHeader(key string) string
+}
+
+// VaryAdapter is implemented by adapters that add the request headers that are read to the Vary response header.
+type VaryAdapter interface {
+	Vary(header string)
+}
+
+// NewAdapter creates an Adapter for the standard library.
+func NewAdapter(w http.ResponseWriter, r *http.Request) Adapter {
+	return httpAdapter{w: w, r: r}
+}
+
+// ReadRequest creates a HeaderReader for the request of the adapter.
+//
+// The headers are added to the Vary response header when the adapter implements VaryAdapter.
+func ReadRequest(a Adapter) HeaderReader {
+	reader := ReadHeaders(a)
+	if v, ok := a.(VaryAdapter); ok {
+		reader = reader.WithVary(v.Vary)
+	}
+
+	return reader
+}
+
+// ResponseFor sets the HTMX headers, and the status code if one is set, using the adapter.
+//
+// Trigger events are merged with the events already set on the response when the adapter
+// implements ResponseHeaderAdapter.
+//
+// See Response for the available options.
+func ResponseFor(a ResponseAdapter, options ...ResponseOption) (*HtmxResponse, error) {
+	o, err := BuildResponse(options...)
+	if err != nil {
+		return nil, err
+	}
+	if h, ok := a.(ResponseHeaderAdapter); ok {
+		if err = o.MergeTriggers(h.ResponseHeader); err != nil {
+			return nil, err
+		}
+	}
+
+	for k, v := range o.headers {
+		a.SetHeader(k, v)
+	}
+
+	// Support setting the stop polling status code.
+	if o.status != 0 {
+		a.SetStatus(o.status)
+	}
+
+	return o, nil
+}
+
+type httpAdapter struct {
+	w http.ResponseWriter
+	r *http.Request
+}
+
+func (a httpAdapter) Get(key string) string            { return a.r.Header.Get(key) }
+func (a httpAdapter) SetHeader(key, value string)      { a.w.Header().Set(key, value) }
+func (a httpAdapter) SetStatus(code int)               { a.w.WriteHeader(code) }
+func (a httpAdapter) ResponseHeader(key string) string { return a.w.Header().Get(key) }
+func (a httpAdapter) Vary(header string)               { varyOn(a.r, header) }
@@ -0,0 +1,50 @@
+package hx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stackus/hxgo"
+	"github.com/stackus/hxgo/hxtest"
+)
+
+func TestRequestConformance(t *testing.T) {
+	t.Parallel()
+
+	hxtest.RunRequestConformance(t, func(t *testing.T, headers map[string]string) hxtest.RequestGetters {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+
+		return hxtest.RequestGetters{
+			IsBoosted:               func() bool { return hx.IsBoosted(r) },
+			GetCurrentUrl:           func() string { return hx.GetCurrentUrl(r) },
+			IsHistoryRestoreRequest: func() bool { return hx.IsHistoryRestoreRequest(r) },
+			GetPrompt:               func() string { return hx.GetPrompt(r) },
+			IsRequest:               func() bool { return hx.IsRequest(r) },
+			IsHtmx:                  func() bool { return hx.IsHtmx(r) },
+			GetTarget:               func() string { return hx.GetTarget(r) },
+			GetTriggerName:          func() string { return hx.GetTriggerName(r) },
+			GetTrigger:              func() string { return hx.GetTrigger(r) },
+			ParseRequest:            func() hx.Request { return hx.ParseRequest(r) },
+		}
+	})
+}
+
+func TestAdapterConformance(t *testing.T) {
+	t.Parallel()
+
+	hxtest.RunAdapterConformance(t, func(t *testing.T, headers map[string]string) (hx.Adapter, hxtest.AdapterResult) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+		wr := httptest.NewRecorder()
+
+		return hx.NewAdapter(wr, r), func() (http.Header, int) {
+			return wr.Header(), wr.Code
+		}
+	})
+}
@@ -0,0 +1,350 @@
+package hx
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventType is a named event with a payload of type T.
+//
+// Define the events once, so the name and the shape of the payload are declared in a
+// single place that can also be used to generate the TypeScript declarations for the client:
+//
+//	type ItemAddedPayload struct {
+//		ID   int    `json:"id"`
+//		Name string `json:"name"`
+//	}
+//
+//	var ItemAdded = hx.DefineEvent[ItemAddedPayload]("item-added")
+//
+//	hx.Response(w, hx.Trigger(ItemAdded.Trigger(ItemAddedPayload{ID: 1, Name: "Widget"})))
+//	// Sets HX-Trigger header to {"item-added":{"id":1,"name":"Widget"}}
+type EventType[T any] struct {
+	name string
+}
+
+// DefineEvent defines an event with a payload of type T in the DefaultEventRegistry.
+//
+// It panics if the name is empty or an event with the same name has already been defined.
+func DefineEvent[T any](name string) EventType[T] {
+	return DefineEventIn[T](DefaultEventRegistry, name)
+}
+
+// DefineEventIn defines an event with a payload of type T in the registry.
+//
+// It panics if the name is empty or an event with the same name has already been defined.
+func DefineEventIn[T any](r *EventRegistry, name string) EventType[T] {
+	r.register(name, reflect.TypeOf((*T)(nil)).Elem())
+	return EventType[T]{name: name}
+}
+
+// Name returns the name of the event.
+func (e EventType[T]) Name() string { return e.name }
+
+// Trigger creates an event with the payload to pass to one of the Trigger options.
+//
+// The event can be targeted at an element in the same way as any other event:
+//
+//	ItemAdded.Trigger(payload).Target("#items")
+func (e EventType[T]) Trigger(payload T) TriggerEvent {
+	return Event(e.name, payload)
+}
+
+// EventRegistry keeps the events created with DefineEvent and DefineEventIn.
+//
+// The zero value is an empty registry ready to use.
+type EventRegistry struct {
+	mu     sync.Mutex
+	events map[string]reflect.Type
+}
+
+// DefaultEventRegistry is the registry used by DefineEvent.
+var DefaultEventRegistry = &EventRegistry{}
+
+func (r *EventRegistry) register(name string, payload reflect.Type) {
+	if name == "" {
+		panic("hx: event name is empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.events[name]; exists {
+		panic(fmt.Sprintf("hx: event %q is already defined", name))
+	}
+	if r.events == nil {
+		r.events = make(map[string]reflect.Type)
+	}
+	r.events[name] = payload
+}
+
+// Names returns the names of the events in the registry in sorted order.
+func (r *EventRegistry) Names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.events))
+	for name := range r.events {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// WriteTypeScript writes a TypeScript declaration of the events in the registry.
+//
+// Each named struct type used by a payload is declared as an interface, and the events are
+// listed in the HxEvents interface, which maps the event names to the types of their payloads:
+//
+//	export interface ItemAddedPayload {
+//		id: number;
+//		name: string;
+//	}
+//
+//	export interface HxEvents {
+//		"item-added": ItemAddedPayload;
+//	}
+//
+// The declarations follow the encoding/json rules: fields are named by their json tags,
+// fields with the omitempty option are optional, and pointers may be null. Types that
+// implement json.Marshaler are declared as unknown, except for time.Time, and types that
+// implement encoding.TextMarshaler are declared as string.
+//
+// An error is returned when a payload type cannot be marshaled to JSON, or when two
+// different types with the same name are used.
+func (r *EventRegistry) WriteTypeScript(w io.Writer) error {
+	r.mu.Lock()
+	events := make(map[string]reflect.Type, len(r.events))
+	for name, payload := range r.events {
+		events[name] = payload
+	}
+	r.mu.Unlock()
+
+	names := make([]string, 0, len(events))
+	for name := range events {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	g := tsGenerator{declared: make(map[string]reflect.Type)}
+	var b strings.Builder
+	b.WriteString("export interface HxEvents {\n")
+	for _, name := range names {
+		ts, err := g.typeOf(events[name])
+		if err != nil {
+			return fmt.Errorf("event %q: %w", name, err)
+		}
+		fmt.Fprintf(&b, "\t%s: %s;\n", tsString(name), tsIndent(ts))
+	}
+	b.WriteString("}\n")
+
+	var out strings.Builder
+	out.WriteString("// Code generated by hxgo. DO NOT EDIT.\n\n")
+	sort.Strings(g.order)
+	for _, name := range g.order {
+		out.WriteString(g.interfaces[name])
+		out.WriteString("\n")
+	}
+	out.WriteString(b.String())
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// tsGenerator declares the named struct types as it finds them
+type tsGenerator struct {
+	declared   map[string]reflect.Type
+	interfaces map[string]string
+	order      []string
+}
+
+func (g *tsGenerator) typeOf(t reflect.Type) (string, error) {
+	switch {
+	case t == timeType:
+		return "string", nil
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return "unknown", nil
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return "string", nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return "number", nil
+	case reflect.String:
+		return "string", nil
+	case reflect.Interface:
+		return "unknown", nil
+	case reflect.Pointer:
+		elem, err := g.typeOf(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return elem + " | null", nil
+	case reflect.Slice, reflect.Array:
+		// byte slices are encoded as base64 strings
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return "string", nil
+		}
+		elem, err := g.typeOf(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]", nil
+	case reflect.Map:
+		key := t.Key().Kind()
+		if key != reflect.String && !t.Key().Implements(textMarshalerType) && (key < reflect.Int || key > reflect.Uintptr) {
+			return "", fmt.Errorf("unsupported map key type %s", t.Key())
+		}
+		elem, err := g.typeOf(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "{ [key: string]: " + elem + " }", nil
+	case reflect.Struct:
+		if t.Name() == "" {
+			fields, err := g.fields(t)
+			if err != nil {
+				return "", err
+			}
+			if fields == "" {
+				return "{}", nil
+			}
+			return "{\n" + fields + "}", nil
+		}
+		return g.declare(t)
+	default:
+		return "", fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// declare adds an interface for the named struct type and returns its name
+func (g *tsGenerator) declare(t reflect.Type) (string, error) {
+	// generic types are named after the type without its type arguments
+	name, _, _ := strings.Cut(t.Name(), "[")
+	if declared, exists := g.declared[name]; exists {
+		if declared != t {
+			return "", fmt.Errorf("types %s and %s are both named %s", declared, t, name)
+		}
+		return name, nil
+	}
+	g.declared[name] = t
+
+	fields, err := g.fields(t)
+	if err != nil {
+		return "", err
+	}
+	if g.interfaces == nil {
+		g.interfaces = make(map[string]string)
+	}
+	g.interfaces[name] = "export interface " + name + " {\n" + fields + "}\n"
+	g.order = append(g.order, name)
+
+	return name, nil
+}
+
+// fields writes the exported fields of the struct in the order they are marshaled
+func (g *tsGenerator) fields(t reflect.Type) (string, error) {
+	var b strings.Builder
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		// untagged embedded structs have their fields promoted
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields, err := g.fields(ft)
+				if err != nil {
+					return "", err
+				}
+				b.WriteString(fields)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		var ts string
+		if hasTagOption(opts, "string") {
+			ts = "string"
+		} else {
+			var err error
+			if ts, err = g.typeOf(f.Type); err != nil {
+				return "", fmt.Errorf("field %s: %w", f.Name, err)
+			}
+		}
+		optional := ""
+		if hasTagOption(opts, "omitempty") {
+			optional = "?"
+		}
+		fmt.Fprintf(&b, "\t%s%s: %s;\n", tsPropertyName(name), optional, tsIndent(ts))
+	}
+
+	return b.String(), nil
+}
+
+// tsIndent indents the lines of a multi-line type so it can be nested in another type
+func tsIndent(ts string) string {
+	return strings.ReplaceAll(ts, "\n", "\n\t")
+}
+
+func hasTagOption(opts, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// tsPropertyName quotes the property name when it is not a valid identifier
+func tsPropertyName(name string) string {
+	for i, c := range name {
+		if c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (i > 0 && c >= '0' && c <= '9') {
+			continue
+		}
+		return tsString(name)
+	}
+	return name
+}
+
+// tsString quotes the string as a JSON string, which is also a valid TypeScript string literal
+func tsString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
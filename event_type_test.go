@@ -0,0 +1,156 @@
+package hx
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type itemAddedPayload struct {
+	ID    int       `json:"id"`
+	Name  string    `json:"name"`
+	Tags  []string  `json:"tags,omitempty"`
+	Price float64   `json:"price,string"`
+	Added time.Time `json:"added"`
+	Owner *owner    `json:"owner"`
+	notes string
+}
+
+type owner struct {
+	auditInfo
+	Name    string `json:"name"`
+	Manager *owner `json:"manager,omitempty"`
+	Secret  string `json:"-"`
+}
+
+type auditInfo struct {
+	CreatedBy string `json:"created-by"`
+}
+
+func TestEventType_Trigger(t *testing.T) {
+	t.Parallel()
+
+	r := &EventRegistry{}
+	itemAdded := DefineEventIn[itemAddedPayload](r, "item-added")
+	cleared := DefineEventIn[*struct{}](r, "cleared")
+
+	w := httptest.NewRecorder()
+	err := Response(w, Trigger(
+		itemAdded.Trigger(itemAddedPayload{ID: 1, Name: "Widget", Added: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}),
+		cleared.Trigger(nil),
+	))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "item-added", itemAdded.Name())
+	assert.Equal(t,
+		`{"item-added":{"id":1,"name":"Widget","price":"0","added":"2024-01-02T00:00:00Z","owner":null},"cleared":null}`,
+		w.Header().Get(HxTrigger),
+	)
+}
+
+func TestDefineEventIn(t *testing.T) {
+	t.Parallel()
+
+	r := &EventRegistry{}
+	DefineEventIn[int](r, "b")
+	DefineEventIn[string](r, "a")
+
+	assert.Equal(t, []string{"a", "b"}, r.Names())
+	assert.PanicsWithValue(t, `hx: event "a" is already defined`, func() { DefineEventIn[int](r, "a") })
+	assert.PanicsWithValue(t, "hx: event name is empty", func() { DefineEventIn[int](r, "") })
+}
+
+func TestEventRegistry_WriteTypeScript(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		define func(r *EventRegistry)
+	}
+	tests := map[string]struct {
+		args    args
+		want    string
+		wantErr bool
+	}{
+		"No events": {
+			args: args{define: func(r *EventRegistry) {}},
+			want: `// Code generated by hxgo. DO NOT EDIT.
+
+export interface HxEvents {
+}
+`,
+		},
+		"Simple payloads": {
+			args: args{define: func(r *EventRegistry) {
+				DefineEventIn[string](r, "message")
+				DefineEventIn[[]int](r, "ids")
+				DefineEventIn[map[string]*bool](r, "flags")
+				DefineEventIn[any](r, "anything")
+			}},
+			want: `// Code generated by hxgo. DO NOT EDIT.
+
+export interface HxEvents {
+	"anything": unknown;
+	"flags": { [key: string]: boolean | null };
+	"ids": number[];
+	"message": string;
+}
+`,
+		},
+		"Struct payloads": {
+			args: args{define: func(r *EventRegistry) {
+				DefineEventIn[itemAddedPayload](r, "item-added")
+				DefineEventIn[struct {
+					Count int `json:"count"`
+				}](r, "counted")
+			}},
+			want: `// Code generated by hxgo. DO NOT EDIT.
+
+export interface itemAddedPayload {
+	id: number;
+	name: string;
+	tags?: string[];
+	price: string;
+	added: string;
+	owner: owner | null;
+}
+
+export interface owner {
+	"created-by": string;
+	name: string;
+	manager?: owner | null;
+}
+
+export interface HxEvents {
+	"counted": {
+		count: number;
+	};
+	"item-added": itemAddedPayload;
+}
+`,
+		},
+		"Unsupported payload": {
+			args: args{define: func(r *EventRegistry) {
+				DefineEventIn[chan int](r, "bad")
+			}},
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &EventRegistry{}
+			tc.args.define(r)
+
+			var b strings.Builder
+			err := r.WriteTypeScript(&b)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, b.String())
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package hx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"net/url"
+	"strings"
+)
+
+// FuncMap returns the functions to render hx-* attributes in html/template.
+//
+// Each function returns the complete attribute, with the value escaped, and fails the
+// template execution when the value is invalid:
+//
+//   - hxVals(value): the hx-vals attribute with the value as a JSON object
+//   - hxHeaders(value): the hx-headers attribute with the value as a JSON object
+//   - hxSwap(swap): the hx-swap attribute from a string, Reswap, or SwapSpec
+//   - hxTrigger(trigger): the hx-trigger attribute from a string, TriggerSpec, or TriggerSpecs
+//   - hxTarget(selector): the hx-target attribute from a string or Selector
+//   - hxGet(url), hxPost(url), hxPut(url), hxPatch(url), hxDelete(url): the request attributes;
+//     only relative URLs and the http and https schemes are allowed
+//
+// Example usage:
+//
+//	tmpl := template.New("page").Funcs(hx.FuncMap())
+//
+//	<button {{ hxPost "/items" }} {{ hxVals .Item }} {{ hxTarget .Target }} {{ hxSwap .Swap }}>Save</button>
+//	// Renders <button hx-post="/items" hx-vals="{&#34;id&#34;:42}" hx-target="#items" hx-swap="beforeend">Save</button>
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"hxVals":    hxVals,
+		"hxHeaders": hxHeaders,
+		"hxSwap":    hxSwap,
+		"hxTrigger": hxTrigger,
+		"hxTarget":  hxTarget,
+		"hxGet":     requestAttr("hx-get"),
+		"hxPost":    requestAttr("hx-post"),
+		"hxPut":     requestAttr("hx-put"),
+		"hxPatch":   requestAttr("hx-patch"),
+		"hxDelete":  requestAttr("hx-delete"),
+	}
+}
+
+func hxVals(value any) (template.HTMLAttr, error) {
+	return jsonObjectAttr("hx-vals", value)
+}
+
+func hxHeaders(value any) (template.HTMLAttr, error) {
+	return jsonObjectAttr("hx-headers", value)
+}
+
+func hxSwap(swap any) (template.HTMLAttr, error) {
+	var err error
+	var value string
+	switch s := swap.(type) {
+	case SwapSpec:
+		value, err = s.String(), s.Validate()
+	case Reswap:
+		value, err = string(s), s.Validate()
+	case string:
+		value, err = s, Reswap(s).Validate()
+	default:
+		err = fmt.Errorf("unsupported type %T", swap)
+	}
+	if err != nil {
+		return "", fmt.Errorf("hx-swap: %w", err)
+	}
+
+	return htmlAttr("hx-swap", value), nil
+}
+
+func hxTrigger(trigger any) (template.HTMLAttr, error) {
+	var specs TriggerSpecs
+	switch t := trigger.(type) {
+	case TriggerSpecs:
+		specs = t
+	case TriggerSpec:
+		specs = TriggerSpecs{t}
+	case string:
+		var err error
+		if specs, err = parseTriggerSpecs(t, true); err != nil {
+			return "", fmt.Errorf("hx-trigger: invalid trigger %q: %w", t, err)
+		}
+	default:
+		return "", fmt.Errorf("hx-trigger: unsupported type %T", trigger)
+	}
+	if err := specs.Validate(); err != nil {
+		return "", fmt.Errorf("hx-trigger: %w", err)
+	}
+
+	return specs.HTMLAttr(), nil
+}
+
+func hxTarget(selector any) (template.HTMLAttr, error) {
+	var value string
+	switch s := selector.(type) {
+	case Selector:
+		value = string(s)
+	case string:
+		value = s
+	default:
+		return "", fmt.Errorf("hx-target: unsupported type %T", selector)
+	}
+	if strings.TrimSpace(value) == "" {
+		return "", fmt.Errorf("hx-target: empty selector")
+	}
+
+	return htmlAttr("hx-target", value), nil
+}
+
+// requestAttr creates the function for one of the request attributes, such as hx-get
+func requestAttr(name string) func(any) (template.HTMLAttr, error) {
+	return func(u any) (template.HTMLAttr, error) {
+		var value string
+		switch v := u.(type) {
+		case string:
+			value = v
+		case fmt.Stringer:
+			value = v.String()
+		default:
+			return "", fmt.Errorf("%s: unsupported type %T", name, u)
+		}
+
+		parsed, err := url.Parse(value)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", name, err)
+		}
+		if parsed.Scheme != "" && !strings.EqualFold(parsed.Scheme, "http") && !strings.EqualFold(parsed.Scheme, "https") {
+			return "", fmt.Errorf("%s: unsafe URL scheme %q", name, parsed.Scheme)
+		}
+
+		return htmlAttr(name, value), nil
+	}
+}
+
+// jsonObjectAttr marshals the value, which must be a JSON object, into the attribute
+func jsonObjectAttr(name string, value any) (template.HTMLAttr, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	if !bytes.HasPrefix(data, []byte("{")) {
+		return "", fmt.Errorf("%s: %T is not a JSON object", name, value)
+	}
+
+	return htmlAttr(name, string(data)), nil
+}
+
+// htmlAttr renders the attribute with the value escaped for a double quoted attribute
+func htmlAttr(name, value string) template.HTMLAttr {
+	return template.HTMLAttr(name + `="` + html.EscapeString(value) + `"`)
+}
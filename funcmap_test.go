@@ -0,0 +1,123 @@
+package hx
+
+import (
+	"bytes"
+	"html/template"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuncMap(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		tmpl    string
+		data    any
+		want    string
+		wantErr bool
+	}{
+		"Vals": {
+			tmpl: `<div {{ hxVals . }}>`,
+			data: map[string]any{"id": 42, "name": `"><script>`},
+			want: `<div hx-vals="{&#34;id&#34;:42,&#34;name&#34;:&#34;\&#34;\u003e\u003cscript\u003e&#34;}">`,
+		},
+		"Vals must be an object": {
+			tmpl:    `<div {{ hxVals . }}>`,
+			data:    []int{1},
+			wantErr: true,
+		},
+		"Headers": {
+			tmpl: `<div {{ hxHeaders . }}>`,
+			data: map[string]string{"X-Token": "a'b"},
+			want: `<div hx-headers="{&#34;X-Token&#34;:&#34;a&#39;b&#34;}">`,
+		},
+		"Swap constant": {
+			tmpl: `<div {{ hxSwap . }}>`,
+			data: SwapBeforeEnd.Settle(time.Second),
+			want: `<div hx-swap="beforeend settle:1s">`,
+		},
+		"Swap spec": {
+			tmpl: `<div {{ hxSwap . }}>`,
+			data: NewSwapSpec(SwapInnerHtml).ShowWindow(ScrollTop),
+			want: `<div hx-swap="innerHTML show:window:top">`,
+		},
+		"Swap string": {
+			tmpl: `<div {{ hxSwap "outerHTML" }}>`,
+			want: `<div hx-swap="outerHTML">`,
+		},
+		"Invalid swap": {
+			tmpl:    `<div {{ hxSwap "innerHtml" }}>`,
+			wantErr: true,
+		},
+		"Trigger spec": {
+			tmpl: `<input {{ hxTrigger . }}>`,
+			data: NewTriggerSpec("keyup").Filter(`key=="Enter"`).Changed(),
+			want: `<input hx-trigger="keyup[key==&#34;Enter&#34;] changed">`,
+		},
+		"Trigger specs": {
+			tmpl: `<div {{ hxTrigger . }}>`,
+			data: TriggerSpecs{NewTriggerSpec(TriggerLoad), Every(2 * time.Second)},
+			want: `<div hx-trigger="load, every 2s">`,
+		},
+		"Trigger string": {
+			tmpl: `<div {{ hxTrigger "revealed once" }}>`,
+			want: `<div hx-trigger="revealed once">`,
+		},
+		"Invalid trigger": {
+			tmpl:    `<div {{ hxTrigger "click queue:later" }}>`,
+			wantErr: true,
+		},
+		"Target selector": {
+			tmpl: `<div {{ hxTarget . }}>`,
+			data: Closest(ID("row-1")),
+			want: `<div hx-target="closest #row-1">`,
+		},
+		"Target string": {
+			tmpl: `<div {{ hxTarget "#list" }}>`,
+			want: `<div hx-target="#list">`,
+		},
+		"Empty target": {
+			tmpl:    `<div {{ hxTarget "" }}>`,
+			wantErr: true,
+		},
+		"Get": {
+			tmpl: `<a {{ hxGet . }}>`,
+			data: "/items?q=a&b=\"c\"",
+			want: `<a hx-get="/items?q=a&amp;b=&#34;c&#34;">`,
+		},
+		"Post URL": {
+			tmpl: `<form {{ hxPost . }}>`,
+			data: &url.URL{Scheme: "https", Host: "example.com", Path: "/items"},
+			want: `<form hx-post="https://example.com/items">`,
+		},
+		"Put, patch and delete": {
+			tmpl: `<div {{ hxPut "/a" }} {{ hxPatch "/b" }} {{ hxDelete "/c" }}>`,
+			want: `<div hx-put="/a" hx-patch="/b" hx-delete="/c">`,
+		},
+		"Unsafe URL scheme": {
+			tmpl:    `<a {{ hxGet "javascript:alert(1)" }}>`,
+			wantErr: true,
+		},
+		"Unsafe URL scheme with whitespace": {
+			tmpl:    `<a {{ hxGet " javascript:alert(1)" }}>`,
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpl := template.Must(template.New("").Funcs(FuncMap()).Parse(tc.tmpl))
+
+			var b bytes.Buffer
+			err := tmpl.Execute(&b, tc.data)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, b.String())
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package hx
+
+import (
+	"net/url"
+	"strings"
+)
+
+// HeaderGetter returns the value of a request header.
+//
+// http.Header implements HeaderGetter. The adapters for other HTTP libraries
+// provide their own so all of them read the HTMX headers the same way.
+type HeaderGetter interface {
+	Get(key string) string
+}
+
+// HeaderReader reads the HTMX request headers from a HeaderGetter.
+//
+// The boolean headers are true when they are present with any value except "false",
+// so "HX-Request: false" is not treated as an HTMX request.
+//
+// Use ReadHeaders to create a HeaderReader for your own HTTP library.
+type HeaderReader struct {
+	headers HeaderGetter
+	vary    func(header string)
+}
+
+// ReadHeaders creates a HeaderReader for the request headers.
+//
+// Example usage:
+//
+//	req := hx.ReadHeaders(r.Header)
+//	if req.IsHtmx() && !req.IsBoosted() {
+//		// render a partial
+//	}
+func ReadHeaders(h HeaderGetter) HeaderReader {
+	return HeaderReader{headers: h}
+}
+
+// WithVary returns a copy of the HeaderReader that calls vary with the name of each header before it is read.
+//
+// It is used to add the headers to the Vary response header, see VaryMiddleware.
+func (r HeaderReader) WithVary(vary func(header string)) HeaderReader {
+	r.vary = vary
+	return r
+}
+
+// IsBoosted returns true if the request is a boosted request.
+func (r HeaderReader) IsBoosted() bool { return r.getBool(HxBoosted) }
+
+// GetCurrentUrl returns the current URL of the browser.
+func (r HeaderReader) GetCurrentUrl() string { return r.get(HxCurrentUrl) }
+
+// IsHistoryRestoreRequest returns true if the request is a history restore request.
+func (r HeaderReader) IsHistoryRestoreRequest() bool { return r.getBool(HxHistoryRestoreRequest) }
+
+// GetPrompt returns the user response to an hx-prompt.
+func (r HeaderReader) GetPrompt() string { return r.get(HxPrompt) }
+
+// IsRequest returns true if the request is an HTMX request.
+func (r HeaderReader) IsRequest() bool { return r.getBool(HxRequest) }
+
+// IsHtmx does the same thing as IsRequest, only with a more user-friendly name.
+func (r HeaderReader) IsHtmx() bool { return r.IsRequest() }
+
+// GetTarget returns the ID of the target element.
+func (r HeaderReader) GetTarget() string { return r.get(HxTarget) }
+
+// GetTriggerName returns the name of the triggered element.
+func (r HeaderReader) GetTriggerName() string { return r.get(HxTriggerName) }
+
+// GetTrigger returns the ID of the triggered element.
+func (r HeaderReader) GetTrigger() string { return r.get(HxTrigger) }
+
+// GetVersion returns the htmx version sent in the VersionHeader.
+func (r HeaderReader) GetVersion() Version { return ParseVersion(r.get(VersionHeader)) }
+
+// Parse parses all the HTMX request headers.
+//
+// Only the headers that commonly change which content is returned, HX-Request, HX-Boosted,
+// HX-History-Restore-Request, and HX-Target, are passed to the vary function. Use the
+// getters for the other headers when the response depends on them.
+func (r HeaderReader) Parse() Request {
+	others := r
+	others.vary = nil
+
+	req := Request{
+		Boosted:               r.IsBoosted(),
+		HistoryRestoreRequest: r.IsHistoryRestoreRequest(),
+		Prompt:                others.GetPrompt(),
+		Request:               r.IsRequest(),
+		Target:                r.GetTarget(),
+		Trigger:               others.GetTrigger(),
+		TriggerName:           others.GetTriggerName(),
+		Version:               others.GetVersion(),
+	}
+	if currentUrl := others.GetCurrentUrl(); currentUrl != "" {
+		if u, err := url.Parse(currentUrl); err == nil {
+			req.CurrentUrl = u
+		}
+	}
+
+	return req
+}
+
+func (r HeaderReader) get(header string) string {
+	if r.vary != nil {
+		r.vary(header)
+	}
+	return r.headers.Get(header)
+}
+
+// getBool treats a missing header, or a header set to "false", as false
+func (r HeaderReader) getBool(header string) bool {
+	value := strings.TrimSpace(r.get(header))
+	return value != "" && !strings.EqualFold(value, "false")
+}
@@ -0,0 +1,81 @@
+package hx
+
+// HeaderDirection tells if a header is sent by htmx in a request or by the server in a response.
+type HeaderDirection string
+
+// Header directions
+const (
+	// RequestHeader is sent by htmx with each request
+	RequestHeader HeaderDirection = "request"
+	// ResponseHeader is sent by the server in a response to htmx
+	ResponseHeader HeaderDirection = "response"
+)
+
+// HeaderFormat describes the value of a header.
+type HeaderFormat string
+
+// Header formats
+const (
+	// FormatBoolean is "true" or "false"
+	FormatBoolean HeaderFormat = "boolean"
+	// FormatText is free text, such as the ID or name of an element
+	FormatText HeaderFormat = "text"
+	// FormatURL is a URL, or "false" to prevent a history update
+	FormatURL HeaderFormat = "url"
+	// FormatSelector is a CSS selector
+	FormatSelector HeaderFormat = "selector"
+	// FormatSwap is an hx-swap value, see Reswap
+	FormatSwap HeaderFormat = "swap"
+	// FormatLocation is a path, or a JSON object of the path and its properties, see Location
+	FormatLocation HeaderFormat = "location"
+	// FormatEvents is a comma separated list of event names, or a JSON object of the event names to their data, see Trigger
+	FormatEvents HeaderFormat = "events"
+)
+
+// HeaderInfo describes one of the official htmx headers.
+//
+// See https://htmx.org/reference/#request_headers and https://htmx.org/reference/#response_headers
+type HeaderInfo struct {
+	// Name is the name of the header as written in the htmx documentation
+	Name string
+	// Direction tells if the header is a request or a response header
+	Direction HeaderDirection
+	// Format describes the value of the header
+	Format HeaderFormat
+	// Since is the first major version of htmx to support the header
+	Since Version
+}
+
+// headers is the table of the official htmx headers.
+//
+// A header that is used in both directions, such as HX-Trigger, has an entry for each direction.
+var headers = []HeaderInfo{
+	{Name: "HX-Boosted", Direction: RequestHeader, Format: FormatBoolean, Since: Version1},
+	{Name: "HX-Current-URL", Direction: RequestHeader, Format: FormatURL, Since: Version1},
+	{Name: "HX-History-Restore-Request", Direction: RequestHeader, Format: FormatBoolean, Since: Version1},
+	{Name: "HX-Prompt", Direction: RequestHeader, Format: FormatText, Since: Version1},
+	{Name: "HX-Request", Direction: RequestHeader, Format: FormatBoolean, Since: Version1},
+	{Name: "HX-Target", Direction: RequestHeader, Format: FormatText, Since: Version1},
+	{Name: "HX-Trigger-Name", Direction: RequestHeader, Format: FormatText, Since: Version1},
+	{Name: "HX-Trigger", Direction: RequestHeader, Format: FormatText, Since: Version1},
+
+	{Name: "HX-Location", Direction: ResponseHeader, Format: FormatLocation, Since: Version1},
+	{Name: "HX-Push-Url", Direction: ResponseHeader, Format: FormatURL, Since: Version1},
+	{Name: "HX-Redirect", Direction: ResponseHeader, Format: FormatURL, Since: Version1},
+	{Name: "HX-Refresh", Direction: ResponseHeader, Format: FormatBoolean, Since: Version1},
+	{Name: "HX-Replace-Url", Direction: ResponseHeader, Format: FormatURL, Since: Version1},
+	{Name: "HX-Reswap", Direction: ResponseHeader, Format: FormatSwap, Since: Version1},
+	{Name: "HX-Retarget", Direction: ResponseHeader, Format: FormatSelector, Since: Version1},
+	{Name: "HX-Reselect", Direction: ResponseHeader, Format: FormatSelector, Since: Version1},
+	{Name: "HX-Trigger", Direction: ResponseHeader, Format: FormatEvents, Since: Version1},
+	{Name: "HX-Trigger-After-Settle", Direction: ResponseHeader, Format: FormatEvents, Since: Version1},
+	{Name: "HX-Trigger-After-Swap", Direction: ResponseHeader, Format: FormatEvents, Since: Version1},
+}
+
+// SupportedHeaders returns the official htmx headers that are supported by this package.
+//
+// Each header has a constant in this package, such as HxTriggerAfterSwap for HX-Trigger-After-Swap.
+// Header names are case-insensitive, so compare them with http.CanonicalHeaderKey or strings.EqualFold.
+func SupportedHeaders() []HeaderInfo {
+	return append([]HeaderInfo(nil), headers...)
+}
@@ -0,0 +1,122 @@
+package hx
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHeaderConstants checks the header constants against the table of official htmx headers
+func TestHeaderConstants(t *testing.T) {
+	t.Parallel()
+
+	constants := make(map[string]string)
+	for _, file := range []string{"request.go", "response.go", "trigger_options.go"} {
+		for name, value := range headerConstants(t, file) {
+			constants[name] = value
+		}
+	}
+
+	official := make(map[string]bool)
+	for _, h := range SupportedHeaders() {
+		official[http.CanonicalHeaderKey(h.Name)] = true
+	}
+
+	found := make(map[string]bool)
+	for name, value := range constants {
+		key := http.CanonicalHeaderKey(value)
+		assert.True(t, official[key], "%s = %q is not an official htmx header", name, value)
+		found[key] = true
+	}
+	for key := range official {
+		assert.True(t, found[key], "no constant for the %s header", key)
+	}
+}
+
+func TestSupportedHeaders(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		header    string
+		direction HeaderDirection
+		want      HeaderInfo
+	}{
+		"Request trigger": {
+			header:    HxTrigger,
+			direction: RequestHeader,
+			want:      HeaderInfo{Name: "HX-Trigger", Direction: RequestHeader, Format: FormatText, Since: Version1},
+		},
+		"Response trigger": {
+			header:    HxTrigger,
+			direction: ResponseHeader,
+			want:      HeaderInfo{Name: "HX-Trigger", Direction: ResponseHeader, Format: FormatEvents, Since: Version1},
+		},
+		"Trigger after swap": {
+			header:    HxTriggerAfterSwap,
+			direction: ResponseHeader,
+			want:      HeaderInfo{Name: "HX-Trigger-After-Swap", Direction: ResponseHeader, Format: FormatEvents, Since: Version1},
+		},
+		"Current URL": {
+			header:    HxCurrentUrl,
+			direction: RequestHeader,
+			want:      HeaderInfo{Name: "HX-Current-URL", Direction: RequestHeader, Format: FormatURL, Since: Version1},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got []HeaderInfo
+			for _, h := range SupportedHeaders() {
+				if strings.EqualFold(h.Name, tc.header) && h.Direction == tc.direction {
+					got = append(got, h)
+				}
+			}
+			assert.Equal(t, []HeaderInfo{tc.want}, got)
+		})
+	}
+
+	// the table cannot be changed through the returned slice
+	SupportedHeaders()[0].Name = "X-Changed"
+	assert.Equal(t, "HX-Boosted", SupportedHeaders()[0].Name)
+}
+
+// headerConstants returns the string constants named Hx... that are declared in the file
+func headerConstants(t *testing.T, file string) map[string]string {
+	t.Helper()
+
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+
+	constants := make(map[string]string)
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if !strings.HasPrefix(name.Name, "Hx") || i >= len(vs.Values) {
+					continue
+				}
+				lit, ok := vs.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				value, err := strconv.Unquote(lit.Value)
+				assert.NoError(t, err)
+				constants[name.Name] = value
+			}
+		}
+	}
+	assert.NotEmpty(t, constants, "no header constants in %s", file)
+
+	return constants
+}
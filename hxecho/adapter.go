@@ -0,0 +1,25 @@
+package hxecho
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/stackus/hxgo"
+)
+
+// NewAdapter creates an hx.Adapter for the echo.Context.
+//
+// The status code is stored on the echo.Response and is written with the response body,
+// unless the handler writes a different status code.
+func NewAdapter(ctx echo.Context) hx.Adapter {
+	return adapter{ctx: ctx}
+}
+
+type adapter struct {
+	ctx echo.Context
+}
+
+func (a adapter) Get(key string) string            { return a.ctx.Request().Header.Get(key) }
+func (a adapter) SetHeader(key, value string)      { a.ctx.Response().Header().Set(key, value) }
+func (a adapter) SetStatus(code int)               { a.ctx.Response().Status = code }
+func (a adapter) ResponseHeader(key string) string { return a.ctx.Response().Header().Get(key) }
+func (a adapter) Vary(header string)               { varyOn(a.ctx, header) }
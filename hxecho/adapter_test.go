@@ -0,0 +1,28 @@
+package hxecho
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/stackus/hxgo"
+	"github.com/stackus/hxgo/hxtest"
+)
+
+func TestAdapterConformance(t *testing.T) {
+	t.Parallel()
+
+	hxtest.RunAdapterConformance(t, func(t *testing.T, headers map[string]string) (hx.Adapter, hxtest.AdapterResult) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+		ctx := echo.New().NewContext(r, httptest.NewRecorder())
+
+		return NewAdapter(ctx), func() (http.Header, int) {
+			return ctx.Response().Header(), ctx.Response().Status
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package hxecho
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/stackus/hxgo"
+)
+
+const (
+	layoutKey     = "hxgo.layout"
+	renderModeKey = "hxgo.renderMode"
+)
+
+// LayoutMiddleware stores the layout in the echo.Context for use by Render.
+//
+// See hx.LayoutMiddleware for more details.
+//
+// Example usage:
+//
+//	e := echo.New()
+//	e.Use(hxecho.LayoutMiddleware(layout))
+func LayoutMiddleware(layout hx.Layout, options ...hx.LayoutOption) echo.MiddlewareFunc {
+	p := hx.NewPageLayout(layout, options...)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			ctx.Set(layoutKey, p)
+			return next(ctx)
+		}
+	}
+}
+
+// RenderModeMiddleware overrides the render mode for the routes it is used with.
+//
+// Example usage:
+//
+//	e.GET("/modal", ModalHandler, hxecho.RenderModeMiddleware(hx.RenderPartial))
+func RenderModeMiddleware(mode hx.RenderMode) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			ctx.Set(renderModeKey, mode)
+			return next(ctx)
+		}
+	}
+}
+
+// Render writes the content, wrapped in the layout set by LayoutMiddleware when the full page
+// should be rendered.
+//
+// See hx.Render for more details.
+func Render(ctx echo.Context, content hx.Fragment) error {
+	fragment := content
+	if p, ok := ctx.Get(layoutKey).(hx.PageLayout); ok {
+		mode, _ := ctx.Get(renderModeKey).(hx.RenderMode)
+		fragment = p.Fragment(layoutRequest(ctx), mode, content)
+	}
+
+	ctx.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return fragment.Render(ctx.Request().Context(), ctx.Response())
+}
+
+// layoutRequest reads the headers used to choose the layout
+func layoutRequest(ctx echo.Context) hx.Request {
+	return hx.Request{
+		Boosted:               IsBoosted(ctx),
+		HistoryRestoreRequest: IsHistoryRestoreRequest(ctx),
+		Request:               IsHtmx(ctx),
+		Target:                GetTarget(ctx),
+	}
+}
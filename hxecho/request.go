@@ -10,7 +10,7 @@ import (
 //
 // Returns true if the request is a boosted request
 func IsBoosted(ctx echo.Context) bool {
-	return ctx.Request().Header.Get(hx.HxBoosted) == "true"
+	return hx.ReadRequest(NewAdapter(ctx)).IsBoosted()
 }
 
 // GetCurrentUrl extracts the HX-Current-URL header from an HTTP request.
@@ -18,15 +18,15 @@ func IsBoosted(ctx echo.Context) bool {
 // It returns the current URL of the browser if the header exists.
 // If the header is not present, it returns an empty string.
 func GetCurrentUrl(ctx echo.Context) string {
-	return ctx.Request().Header.Get(hx.HxCurrentUrl)
+	return hx.ReadRequest(NewAdapter(ctx)).GetCurrentUrl()
 }
 
 // IsHistoryRestoreRequest determines if an HTTP request is a history restore request.
 //
 // It checks the presence of the HX-History-Restore-Request header in the request.
-// Returns true if the header is present, otherwise returns false.
+// Returns true if the header is present and not "false", otherwise returns false.
 func IsHistoryRestoreRequest(ctx echo.Context) bool {
-	return ctx.Request().Header.Get(hx.HxHistoryRestoreRequest) != ""
+	return hx.ReadRequest(NewAdapter(ctx)).IsHistoryRestoreRequest()
 }
 
 // GetPrompt extracts the HX-Prompt header from an HTTP request.
@@ -34,15 +34,15 @@ func IsHistoryRestoreRequest(ctx echo.Context) bool {
 // It returns the user response to an Hx-Prompt if the header exists.
 // If the header is not present, it returns an empty string.
 func GetPrompt(ctx echo.Context) string {
-	return ctx.Request().Header.Get(hx.HxPrompt)
+	return hx.ReadRequest(NewAdapter(ctx)).GetPrompt()
 }
 
 // IsRequest determines if an HTTP request is an HTMX request.
 //
 // It checks the presence of the HX-Request header in the request.
-// Returns true if the header is present, otherwise returns false.
+// Returns true if the header is present and not "false", otherwise returns false.
 func IsRequest(ctx echo.Context) bool {
-	return ctx.Request().Header.Get(hx.HxRequest) != ""
+	return hx.ReadRequest(NewAdapter(ctx)).IsRequest()
 }
 
 // IsHtmx determines if an HTTP request is an HTMX request.
@@ -57,7 +57,7 @@ func IsHtmx(ctx echo.Context) bool {
 // It returns the ID of the target element if the header exists.
 // If the header is not present, it returns an empty string.
 func GetTarget(ctx echo.Context) string {
-	return ctx.Request().Header.Get(hx.HxTarget)
+	return hx.ReadRequest(NewAdapter(ctx)).GetTarget()
 }
 
 // GetTriggerName extracts the HX-Trigger-Name header from an HTTP request.
@@ -65,7 +65,7 @@ func GetTarget(ctx echo.Context) string {
 // It returns the name of the triggered element if the header exists.
 // If the header is not present, it returns an empty string.
 func GetTriggerName(ctx echo.Context) string {
-	return ctx.Request().Header.Get(hx.HxTriggerName)
+	return hx.ReadRequest(NewAdapter(ctx)).GetTriggerName()
 }
 
 // GetTrigger extracts the HX-Trigger header from an HTTP request.
@@ -73,5 +73,19 @@ func GetTriggerName(ctx echo.Context) string {
 // It returns the ID of the trigger element if the header exists.
 // If the header is not present, it returns an empty string.
 func GetTrigger(ctx echo.Context) string {
-	return ctx.Request().Header.Get(hx.HxTrigger)
+	return hx.ReadRequest(NewAdapter(ctx)).GetTrigger()
+}
+
+// GetVersion detects the htmx version from the hx.VersionHeader of an HTTP request.
+//
+// It returns hx.VersionUnspecified if the header is not present.
+func GetVersion(ctx echo.Context) hx.Version {
+	return hx.ReadRequest(NewAdapter(ctx)).GetVersion()
+}
+
+// ParseRequest parses all the HTMX request headers from an HTTP request.
+//
+// It returns the same hx.Request as hx.ParseRequest.
+func ParseRequest(ctx echo.Context) hx.Request {
+	return hx.ReadRequest(NewAdapter(ctx)).Parse()
 }
@@ -8,8 +8,9 @@ import (
 
 // Response modifies the echo.Context to add HTMX headers and status codes.
 //
-// This will set the HTMX headers but will not set the Status Code. Use the
-// returned response to set the Status Code later with `response.StatusCode()`.
+// The Status Code is recorded without writing the response, and a Status Code passed when
+// writing the body takes its place. Use the returned response to write the body with the
+// same Status Code using `response.StatusCode()`.
 //
 // The following options are available:
 //   - Status(int) | StatusStopPolling: Sets the HTTP status code of the HTMX response.
@@ -19,22 +20,11 @@ import (
 //   - Refresh(bool): If set to "true", triggers a full refresh of the client-side page.
 //   - ReplaceUrl(string): Replaces the current URL in the location bar.
 //   - Reswap(string) | {Swap constants}: Specifies how the response will be swapped.
-//   - Retarget(string): A CSS selector to update the target of the content update to a different page element.
-//   - Reselect(string): A CSS selector to select a part of the response to be swapped in, overriding existing hx-select on the triggering element.
+//   - Retarget(string | Selector): A CSS selector to update the target of the content update to a different page element.
+//   - Reselect(string | Selector): A CSS selector to select a part of the response to be swapped in, overriding existing hx-select on the triggering element.
 //   - Trigger(...events): Triggers client-side events.
 //   - TriggerAfterSettle(...events): Triggers client-side events after the settle step.
 //   - TriggerAfterSwap(...events): Triggers client-side events after the swap step.
 func Response(ctx echo.Context, options ...hx.ResponseOption) (*hx.HtmxResponse, error) {
-	r, err := hx.BuildResponse(options...)
-	if err != nil {
-		return nil, err
-	}
-
-	for k, v := range r.Headers() {
-		ctx.Response().Header().Set(k, v)
-	}
-
-	// Skip setting the Status Code for Echo to avoid superfluous write errors
-
-	return r, nil
+	return hx.ResponseFor(NewAdapter(ctx), options...)
 }
@@ -0,0 +1,31 @@
+package hxecho
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/stackus/hxgo"
+)
+
+const varyKey = "hxgo.vary"
+
+// VaryMiddleware adds the HTMX request headers that a handler reads to the Vary response header.
+//
+// See hx.VaryMiddleware for more details.
+//
+// Example usage:
+//
+//	e := echo.New()
+//	e.Use(hxecho.VaryMiddleware)
+func VaryMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		ctx.Set(varyKey, true)
+		return next(ctx)
+	}
+}
+
+// varyOn records the header in the Vary header of the response when VaryMiddleware is in use
+func varyOn(ctx echo.Context, header string) {
+	if enabled, _ := ctx.Get(varyKey).(bool); enabled {
+		hx.AddVary(ctx.Response().Header(), header)
+	}
+}
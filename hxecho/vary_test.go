@@ -0,0 +1,74 @@
+package hxecho
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stackus/hxgo"
+)
+
+func TestVaryMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		handler echo.HandlerFunc
+		want    []string
+	}{
+		"No headers read": {
+			handler: func(ctx echo.Context) error { return nil },
+			want:    nil,
+		},
+		"Read every header once": {
+			handler: func(ctx echo.Context) error {
+				_ = IsHtmx(ctx)
+				_ = IsHtmx(ctx)
+				_ = GetTarget(ctx)
+				return nil
+			},
+			want: []string{hx.HxRequest, hx.HxTarget},
+		},
+		"ParseRequest": {
+			handler: func(ctx echo.Context) error {
+				_ = ParseRequest(ctx)
+				return nil
+			},
+			want: []string{
+				hx.HxBoosted,
+				hx.HxHistoryRestoreRequest,
+				hx.HxRequest,
+				hx.HxTarget,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			e.Use(VaryMiddleware)
+			e.GET("/", tt.handler)
+
+			wr := httptest.NewRecorder()
+			e.ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tt.want, wr.Header().Values("Vary"))
+		})
+	}
+}
+
+func TestVaryMiddleware_NotUsed(t *testing.T) {
+	t.Parallel()
+
+	e := echo.New()
+	e.GET("/", func(ctx echo.Context) error {
+		_ = ParseRequest(ctx)
+		return nil
+	})
+
+	wr := httptest.NewRecorder()
+	e.ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Empty(t, wr.Header().Values("Vary"))
+}
@@ -0,0 +1,24 @@
+package hxfasthttp
+
+import (
+	"github.com/valyala/fasthttp"
+
+	"github.com/stackus/hxgo"
+)
+
+// NewAdapter creates an hx.Adapter for the fasthttp.RequestCtx.
+func NewAdapter(ctx *fasthttp.RequestCtx) hx.Adapter {
+	return adapter{ctx: ctx}
+}
+
+type adapter struct {
+	ctx *fasthttp.RequestCtx
+}
+
+func (a adapter) Get(key string) string       { return string(a.ctx.Request.Header.Peek(key)) }
+func (a adapter) SetHeader(key, value string) { a.ctx.Response.Header.Set(key, value) }
+func (a adapter) SetStatus(code int)          { a.ctx.SetStatusCode(code) }
+func (a adapter) ResponseHeader(key string) string {
+	return string(a.ctx.Response.Header.Peek(key))
+}
+func (a adapter) Vary(header string) { varyOn(a.ctx, header) }
@@ -0,0 +1,91 @@
+package hxfasthttp
+
+import (
+	"github.com/valyala/fasthttp"
+
+	"github.com/stackus/hxgo"
+)
+
+// IsBoosted checks the HX-Boosted header
+//
+// Returns true if the request is a boosted request
+func IsBoosted(ctx *fasthttp.RequestCtx) bool {
+	return hx.ReadRequest(NewAdapter(ctx)).IsBoosted()
+}
+
+// GetCurrentUrl extracts the HX-Current-URL header from an HTTP request.
+//
+// It returns the current URL of the browser if the header exists.
+// If the header is not present, it returns an empty string.
+func GetCurrentUrl(ctx *fasthttp.RequestCtx) string {
+	return hx.ReadRequest(NewAdapter(ctx)).GetCurrentUrl()
+}
+
+// IsHistoryRestoreRequest determines if an HTTP request is a history restore request.
+//
+// It checks the presence of the HX-History-Restore-Request header in the request.
+// Returns true if the header is present and not "false", otherwise returns false.
+func IsHistoryRestoreRequest(ctx *fasthttp.RequestCtx) bool {
+	return hx.ReadRequest(NewAdapter(ctx)).IsHistoryRestoreRequest()
+}
+
+// GetPrompt extracts the HX-Prompt header from an HTTP request.
+//
+// It returns the user response to an Hx-Prompt if the header exists.
+// If the header is not present, it returns an empty string.
+func GetPrompt(ctx *fasthttp.RequestCtx) string {
+	return hx.ReadRequest(NewAdapter(ctx)).GetPrompt()
+}
+
+// IsRequest determines if an HTTP request is an HTMX request.
+//
+// It checks the presence of the HX-Request header in the request.
+// Returns true if the header is present and not "false", otherwise returns false.
+func IsRequest(ctx *fasthttp.RequestCtx) bool {
+	return hx.ReadRequest(NewAdapter(ctx)).IsRequest()
+}
+
+// IsHtmx determines if an HTTP request is an HTMX request.
+//
+// Does the same thing as IsRequest, only with a more user-friendly name.
+func IsHtmx(ctx *fasthttp.RequestCtx) bool {
+	return IsRequest(ctx)
+}
+
+// GetTarget extracts the HX-Target header from an HTTP request.
+//
+// It returns the ID of the target element if the header exists.
+// If the header is not present, it returns an empty string.
+func GetTarget(ctx *fasthttp.RequestCtx) string {
+	return hx.ReadRequest(NewAdapter(ctx)).GetTarget()
+}
+
+// GetTriggerName extracts the HX-Trigger-Name header from an HTTP request.
+//
+// It returns the name of the triggered element if the header exists.
+// If the header is not present, it returns an empty string.
+func GetTriggerName(ctx *fasthttp.RequestCtx) string {
+	return hx.ReadRequest(NewAdapter(ctx)).GetTriggerName()
+}
+
+// GetTrigger extracts the HX-Trigger header from an HTTP request.
+//
+// It returns the ID of the trigger element if the header exists.
+// If the header is not present, it returns an empty string.
+func GetTrigger(ctx *fasthttp.RequestCtx) string {
+	return hx.ReadRequest(NewAdapter(ctx)).GetTrigger()
+}
+
+// GetVersion detects the htmx version from the hx.VersionHeader of an HTTP request.
+//
+// It returns hx.VersionUnspecified if the header is not present.
+func GetVersion(ctx *fasthttp.RequestCtx) hx.Version {
+	return hx.ReadRequest(NewAdapter(ctx)).GetVersion()
+}
+
+// ParseRequest parses all the HTMX request headers from an HTTP request.
+//
+// It returns the same hx.Request as hx.ParseRequest.
+func ParseRequest(ctx *fasthttp.RequestCtx) hx.Request {
+	return hx.ReadRequest(NewAdapter(ctx)).Parse()
+}
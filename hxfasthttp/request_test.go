@@ -0,0 +1,118 @@
+package hxfasthttp
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+
+	"github.com/stackus/hxgo"
+	"github.com/stackus/hxgo/hxtest"
+)
+
+func TestRequestConformance(t *testing.T) {
+	t.Parallel()
+
+	hxtest.RunRequestConformance(t, func(t *testing.T, headers map[string]string) hxtest.RequestGetters {
+		var g struct {
+			boosted, historyRestoreRequest, request, htmx bool
+			currentUrl, prompt, target, triggerName       string
+			trigger                                       string
+			parsed                                        hx.Request
+		}
+		do(t, func(ctx *fasthttp.RequestCtx) {
+			g.boosted = IsBoosted(ctx)
+			g.currentUrl = GetCurrentUrl(ctx)
+			g.historyRestoreRequest = IsHistoryRestoreRequest(ctx)
+			g.prompt = GetPrompt(ctx)
+			g.request = IsRequest(ctx)
+			g.htmx = IsHtmx(ctx)
+			g.target = GetTarget(ctx)
+			g.triggerName = GetTriggerName(ctx)
+			g.trigger = GetTrigger(ctx)
+			g.parsed = ParseRequest(ctx)
+		}, headers)
+
+		return hxtest.RequestGetters{
+			IsBoosted:               func() bool { return g.boosted },
+			GetCurrentUrl:           func() string { return g.currentUrl },
+			IsHistoryRestoreRequest: func() bool { return g.historyRestoreRequest },
+			GetPrompt:               func() string { return g.prompt },
+			IsRequest:               func() bool { return g.request },
+			IsHtmx:                  func() bool { return g.htmx },
+			GetTarget:               func() string { return g.target },
+			GetTriggerName:          func() string { return g.triggerName },
+			GetTrigger:              func() string { return g.trigger },
+			ParseRequest:            func() hx.Request { return g.parsed },
+		}
+	})
+}
+
+func TestVaryMiddleware(t *testing.T) {
+	t.Parallel()
+
+	resp := do(t, VaryMiddleware(func(ctx *fasthttp.RequestCtx) {
+		_ = IsHtmx(ctx)
+		_ = IsHtmx(ctx)
+		_ = GetTarget(ctx)
+	}), map[string]string{hx.HxRequest: "true"})
+
+	var vary []string
+	resp.Header.VisitAll(func(key, value []byte) {
+		if string(key) == "Vary" {
+			vary = append(vary, string(value))
+		}
+	})
+	assert.Equal(t, []string{hx.HxRequest, hx.HxTarget}, vary)
+}
+
+func TestVaryMiddleware_ParseRequest(t *testing.T) {
+	t.Parallel()
+
+	resp := do(t, VaryMiddleware(func(ctx *fasthttp.RequestCtx) {
+		_ = ParseRequest(ctx)
+	}), nil)
+
+	var vary []string
+	resp.Header.VisitAll(func(key, value []byte) {
+		if string(key) == "Vary" {
+			vary = append(vary, string(value))
+		}
+	})
+	assert.Equal(t, []string{
+		hx.HxBoosted,
+		hx.HxHistoryRestoreRequest,
+		hx.HxRequest,
+		hx.HxTarget,
+	}, vary)
+}
+
+// do sends a request with the headers to the handler using an in-memory listener
+func do(t *testing.T, handler fasthttp.RequestHandler, headers map[string]string) *fasthttp.Response {
+	t.Helper()
+
+	ln := fasthttputil.NewInmemoryListener()
+	s := &fasthttp.Server{Handler: handler}
+	go func() { _ = s.Serve(ln) }()
+
+	client := &fasthttp.Client{
+		Dial: func(string) (net.Conn, error) { return ln.Dial() },
+	}
+	t.Cleanup(func() {
+		client.CloseIdleConnections()
+		_ = ln.Close()
+	})
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	req.SetRequestURI("http://localhost/")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp := &fasthttp.Response{}
+	assert.NoError(t, client.Do(req, resp))
+
+	return resp
+}
@@ -0,0 +1,26 @@
+package hxfasthttp
+
+import (
+	"github.com/valyala/fasthttp"
+
+	"github.com/stackus/hxgo"
+)
+
+// Response modifies the fasthttp.RequestCtx to add HTMX headers and status codes.
+//
+// The following options are available:
+//   - Status(int) | StatusStopPolling: Sets the HTTP status code of the HTMX response.
+//   - Location(path, ...properties): Enables client-side redirection without a full page reload.
+//   - PushUrl(string): Pushes a new URL into the history stack.
+//   - Redirect(string): Performs a client-side redirect with a full page reload.
+//   - Refresh(bool): If set to "true", triggers a full refresh of the client-side page.
+//   - ReplaceUrl(string): Replaces the current URL in the location bar.
+//   - Reswap(string) | {Swap constants}: Specifies how the response will be swapped.
+//   - Retarget(string | Selector): A CSS selector to update the target of the content update to a different page element.
+//   - Reselect(string | Selector): A CSS selector to select a part of the response to be swapped in, overriding existing hx-select on the triggering element.
+//   - Trigger(...events): Triggers client-side events.
+//   - TriggerAfterSettle(...events): Triggers client-side events after the settle step.
+//   - TriggerAfterSwap(...events): Triggers client-side events after the swap step.
+func Response(ctx *fasthttp.RequestCtx, options ...hx.ResponseOption) (*hx.HtmxResponse, error) {
+	return hx.ResponseFor(NewAdapter(ctx), options...)
+}
@@ -0,0 +1,81 @@
+package hxfasthttp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+
+	"github.com/stackus/hxgo"
+)
+
+func TestResponse(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		respond     func(ctx *fasthttp.RequestCtx) error
+		wantHeaders map[string]string
+		wantStatus  int
+		wantErr     bool
+	}{
+		"Set headers": {
+			respond: func(ctx *fasthttp.RequestCtx) error {
+				_, err := Response(ctx, hx.Retarget("#main"), hx.PushUrl("/items"))
+				return err
+			},
+			wantHeaders: map[string]string{
+				hx.HxRetarget: "#main",
+				hx.HxPushUrl:  "/items",
+			},
+			wantStatus: http.StatusOK,
+		},
+		"Set status": {
+			respond: func(ctx *fasthttp.RequestCtx) error {
+				_, err := Response(ctx, hx.StatusStopPolling)
+				return err
+			},
+			wantStatus: int(hx.StatusStopPolling),
+		},
+		"Merge triggers": {
+			respond: func(ctx *fasthttp.RequestCtx) error {
+				if _, err := Response(ctx, hx.Trigger(hx.Event("a"))); err != nil {
+					return err
+				}
+				_, err := Response(ctx, hx.Trigger(hx.Event("b", 1)))
+				return err
+			},
+			wantHeaders: map[string]string{
+				hx.HxTrigger: `{"a":null,"b":1}`,
+			},
+			wantStatus: http.StatusOK,
+		},
+		"Bad event data": {
+			respond: func(ctx *fasthttp.RequestCtx) error {
+				_, err := Response(ctx, hx.Trigger(hx.Event("a", make(chan int))))
+				return err
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// the handler runs on the server goroutine, so its error is sent back to the test
+			errs := make(chan error, 1)
+			resp := do(t, func(ctx *fasthttp.RequestCtx) {
+				errs <- tc.respond(ctx)
+			}, nil)
+
+			if err := <-errs; tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			for k, v := range tc.wantHeaders {
+				assert.Equal(t, v, string(resp.Header.Peek(k)))
+			}
+			assert.Equal(t, tc.wantStatus, resp.StatusCode())
+		})
+	}
+}
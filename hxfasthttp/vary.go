@@ -0,0 +1,43 @@
+package hxfasthttp
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/stackus/hxgo"
+)
+
+const varyKey = "hxgo.vary"
+
+// VaryMiddleware adds the HTMX request headers that a handler reads to the Vary response header.
+//
+// See hx.VaryMiddleware for more details.
+//
+// Example usage:
+//
+//	fasthttp.ListenAndServe(":8080", hxfasthttp.VaryMiddleware(handler))
+func VaryMiddleware(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetUserValue(varyKey, true)
+		next(ctx)
+	}
+}
+
+// varyOn records the header in the Vary header of the response when VaryMiddleware is in use
+func varyOn(ctx *fasthttp.RequestCtx, header string) {
+	if enabled, _ := ctx.UserValue(varyKey).(bool); !enabled {
+		return
+	}
+	h := http.Header{}
+	ctx.Response.Header.VisitAll(func(key, value []byte) {
+		if strings.EqualFold(string(key), "Vary") {
+			h.Add("Vary", string(value))
+		}
+	})
+	count := len(h.Values("Vary"))
+	if hx.AddVary(h, header); len(h.Values("Vary")) > count {
+		ctx.Response.Header.Add("Vary", header)
+	}
+}
@@ -0,0 +1,22 @@
+package hxfiber
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/stackus/hxgo"
+)
+
+// NewAdapter creates an hx.Adapter for the fiber.Ctx.
+func NewAdapter(ctx *fiber.Ctx) hx.Adapter {
+	return adapter{ctx: ctx}
+}
+
+type adapter struct {
+	ctx *fiber.Ctx
+}
+
+func (a adapter) Get(key string) string            { return a.ctx.Get(key) }
+func (a adapter) SetHeader(key, value string)      { a.ctx.Set(key, value) }
+func (a adapter) SetStatus(code int)               { a.ctx.Status(code) }
+func (a adapter) ResponseHeader(key string) string { return a.ctx.GetRespHeader(key) }
+func (a adapter) Vary(header string)               { varyOn(a.ctx, header) }
@@ -0,0 +1,33 @@
+package hxfiber
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+
+	"github.com/stackus/hxgo"
+	"github.com/stackus/hxgo/hxtest"
+)
+
+func TestAdapterConformance(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	hxtest.RunAdapterConformance(t, func(t *testing.T, headers map[string]string) (hx.Adapter, hxtest.AdapterResult) {
+		ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+		t.Cleanup(func() { app.ReleaseCtx(ctx) })
+		for k, v := range headers {
+			ctx.Request().Header.Set(k, v)
+		}
+
+		return NewAdapter(ctx), func() (http.Header, int) {
+			h := http.Header{}
+			ctx.Response().Header.VisitAll(func(key, value []byte) {
+				h.Add(string(key), string(value))
+			})
+			return h, ctx.Response().StatusCode()
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package hxfiber
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/stackus/hxgo"
+)
+
+const (
+	layoutKey     = "hxgo.layout"
+	renderModeKey = "hxgo.renderMode"
+)
+
+// LayoutMiddleware stores the layout in the fiber.Ctx for use by Render.
+//
+// See hx.LayoutMiddleware for more details.
+//
+// Example usage:
+//
+//	app := fiber.New()
+//	app.Use(hxfiber.LayoutMiddleware(layout))
+func LayoutMiddleware(layout hx.Layout, options ...hx.LayoutOption) fiber.Handler {
+	p := hx.NewPageLayout(layout, options...)
+	return func(ctx *fiber.Ctx) error {
+		ctx.Locals(layoutKey, p)
+		return ctx.Next()
+	}
+}
+
+// RenderModeMiddleware overrides the render mode for the routes it is used with.
+//
+// Example usage:
+//
+//	app.Get("/modal", hxfiber.RenderModeMiddleware(hx.RenderPartial), ModalHandler)
+func RenderModeMiddleware(mode hx.RenderMode) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		ctx.Locals(renderModeKey, mode)
+		return ctx.Next()
+	}
+}
+
+// Render writes the content, wrapped in the layout set by LayoutMiddleware when the full page
+// should be rendered.
+//
+// See hx.Render for more details.
+func Render(ctx *fiber.Ctx, content hx.Fragment) error {
+	fragment := content
+	if p, ok := ctx.Locals(layoutKey).(hx.PageLayout); ok {
+		mode, _ := ctx.Locals(renderModeKey).(hx.RenderMode)
+		fragment = p.Fragment(layoutRequest(ctx), mode, content)
+	}
+
+	ctx.Type("html", "utf-8")
+	return fragment.Render(ctx.UserContext(), ctx)
+}
+
+// layoutRequest reads the headers used to choose the layout
+func layoutRequest(ctx *fiber.Ctx) hx.Request {
+	return hx.Request{
+		Boosted:               IsBoosted(ctx),
+		HistoryRestoreRequest: IsHistoryRestoreRequest(ctx),
+		Request:               IsHtmx(ctx),
+		Target:                GetTarget(ctx),
+	}
+}
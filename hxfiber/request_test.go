@@ -0,0 +1,37 @@
+package hxfiber
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+
+	"github.com/stackus/hxgo"
+	"github.com/stackus/hxgo/hxtest"
+)
+
+func TestRequestConformance(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	hxtest.RunRequestConformance(t, func(t *testing.T, headers map[string]string) hxtest.RequestGetters {
+		ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+		t.Cleanup(func() { app.ReleaseCtx(ctx) })
+		for k, v := range headers {
+			ctx.Request().Header.Set(k, v)
+		}
+
+		return hxtest.RequestGetters{
+			IsBoosted:               func() bool { return IsBoosted(ctx) },
+			GetCurrentUrl:           func() string { return GetCurrentUrl(ctx) },
+			IsHistoryRestoreRequest: func() bool { return IsHistoryRestoreRequest(ctx) },
+			GetPrompt:               func() string { return GetPrompt(ctx) },
+			IsRequest:               func() bool { return IsRequest(ctx) },
+			IsHtmx:                  func() bool { return IsHtmx(ctx) },
+			GetTarget:               func() string { return GetTarget(ctx) },
+			GetTriggerName:          func() string { return GetTriggerName(ctx) },
+			GetTrigger:              func() string { return GetTrigger(ctx) },
+			ParseRequest:            func() hx.Request { return ParseRequest(ctx) },
+		}
+	})
+}
@@ -16,24 +16,11 @@ import (
 //   - Refresh(bool): If set to "true", triggers a full refresh of the client-side page.
 //   - ReplaceUrl(string): Replaces the current URL in the location bar.
 //   - Reswap(string) | {Swap constants}: Specifies how the response will be swapped.
-//   - Retarget(string): A CSS selector to update the target of the content update to a different page element.
-//   - Reselect(string): A CSS selector to select a part of the response to be swapped in, overriding existing hx-select on the triggering element.
+//   - Retarget(string | Selector): A CSS selector to update the target of the content update to a different page element.
+//   - Reselect(string | Selector): A CSS selector to select a part of the response to be swapped in, overriding existing hx-select on the triggering element.
 //   - Trigger(...events): Triggers client-side events.
 //   - TriggerAfterSettle(...events): Triggers client-side events after the settle step.
 //   - TriggerAfterSwap(...events): Triggers client-side events after the swap step.
 func Response(ctx *fiber.Ctx, options ...hx.ResponseOption) (*hx.HtmxResponse, error) {
-	r, err := hx.BuildResponse(options...)
-	if err != nil {
-		return nil, err
-	}
-
-	for k, v := range r.Headers() {
-		ctx.Set(k, v)
-	}
-
-	if r.StatusCode() != 0 {
-		ctx.Status(r.StatusCode())
-	}
-
-	return r, nil
+	return hx.ResponseFor(NewAdapter(ctx), options...)
 }
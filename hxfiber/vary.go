@@ -0,0 +1,27 @@
+package hxfiber
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+const varyKey = "hxgo.vary"
+
+// VaryMiddleware adds the HTMX request headers that a handler reads to the Vary response header.
+//
+// See hx.VaryMiddleware for more details.
+//
+// Example usage:
+//
+//	app := fiber.New()
+//	app.Use(hxfiber.VaryMiddleware)
+func VaryMiddleware(ctx *fiber.Ctx) error {
+	ctx.Locals(varyKey, true)
+	return ctx.Next()
+}
+
+// varyOn records the header in the Vary header of the response when VaryMiddleware is in use
+func varyOn(ctx *fiber.Ctx, header string) {
+	if enabled, _ := ctx.Locals(varyKey).(bool); enabled {
+		ctx.Vary(header)
+	}
+}
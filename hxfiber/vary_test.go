@@ -0,0 +1,75 @@
+package hxfiber
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stackus/hxgo"
+)
+
+func TestVaryMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		handler fiber.Handler
+		want    []string
+	}{
+		"No headers read": {
+			handler: func(ctx *fiber.Ctx) error { return nil },
+			want:    nil,
+		},
+		"Read every header once": {
+			handler: func(ctx *fiber.Ctx) error {
+				_ = IsHtmx(ctx)
+				_ = IsHtmx(ctx)
+				_ = GetTarget(ctx)
+				return nil
+			},
+			want: []string{hx.HxRequest + ", " + hx.HxTarget},
+		},
+		"ParseRequest": {
+			handler: func(ctx *fiber.Ctx) error {
+				_ = ParseRequest(ctx)
+				return nil
+			},
+			want: []string{strings.Join([]string{
+				hx.HxBoosted,
+				hx.HxHistoryRestoreRequest,
+				hx.HxRequest,
+				hx.HxTarget,
+			}, ", ")},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(VaryMiddleware)
+			app.Get("/", tt.handler)
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.want, resp.Header.Values("Vary"))
+		})
+	}
+}
+
+func TestVaryMiddleware_NotUsed(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	app.Get("/", func(ctx *fiber.Ctx) error {
+		_ = ParseRequest(ctx)
+		return nil
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.NoError(t, err)
+
+	assert.Empty(t, resp.Header.Values("Vary"))
+}
@@ -0,0 +1,22 @@
+package hxgin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/stackus/hxgo"
+)
+
+// NewAdapter creates an hx.Adapter for the gin.Context.
+func NewAdapter(ctx *gin.Context) hx.Adapter {
+	return adapter{ctx: ctx}
+}
+
+type adapter struct {
+	ctx *gin.Context
+}
+
+func (a adapter) Get(key string) string            { return a.ctx.Request.Header.Get(key) }
+func (a adapter) SetHeader(key, value string)      { a.ctx.Header(key, value) }
+func (a adapter) SetStatus(code int)               { a.ctx.Status(code) }
+func (a adapter) ResponseHeader(key string) string { return a.ctx.Writer.Header().Get(key) }
+func (a adapter) Vary(header string)               { varyOn(a.ctx, header) }
@@ -0,0 +1,29 @@
+package hxgin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/stackus/hxgo"
+	"github.com/stackus/hxgo/hxtest"
+)
+
+func TestAdapterConformance(t *testing.T) {
+	t.Parallel()
+
+	hxtest.RunAdapterConformance(t, func(t *testing.T, headers map[string]string) (hx.Adapter, hxtest.AdapterResult) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = r
+
+		return NewAdapter(ctx), func() (http.Header, int) {
+			return ctx.Writer.Header(), ctx.Writer.Status()
+		}
+	})
+}
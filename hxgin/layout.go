@@ -0,0 +1,65 @@
+package hxgin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/stackus/hxgo"
+)
+
+const (
+	layoutKey     = "hxgo.layout"
+	renderModeKey = "hxgo.renderMode"
+)
+
+// LayoutMiddleware stores the layout in the gin.Context for use by Render.
+//
+// See hx.LayoutMiddleware for more details.
+//
+// Example usage:
+//
+//	r := gin.Default()
+//	r.Use(hxgin.LayoutMiddleware(layout))
+func LayoutMiddleware(layout hx.Layout, options ...hx.LayoutOption) gin.HandlerFunc {
+	p := hx.NewPageLayout(layout, options...)
+	return func(ctx *gin.Context) {
+		ctx.Set(layoutKey, p)
+		ctx.Next()
+	}
+}
+
+// RenderModeMiddleware overrides the render mode for the routes it is used with.
+//
+// Example usage:
+//
+//	r.GET("/modal", hxgin.RenderModeMiddleware(hx.RenderPartial), ModalHandler)
+func RenderModeMiddleware(mode hx.RenderMode) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(renderModeKey, mode)
+		ctx.Next()
+	}
+}
+
+// Render writes the content, wrapped in the layout set by LayoutMiddleware when the full page
+// should be rendered.
+//
+// See hx.Render for more details.
+func Render(ctx *gin.Context, content hx.Fragment) error {
+	fragment := content
+	if p, ok := ctx.Value(layoutKey).(hx.PageLayout); ok {
+		mode, _ := ctx.Value(renderModeKey).(hx.RenderMode)
+		fragment = p.Fragment(layoutRequest(ctx), mode, content)
+	}
+
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	return fragment.Render(ctx.Request.Context(), ctx.Writer)
+}
+
+// layoutRequest reads the headers used to choose the layout
+func layoutRequest(ctx *gin.Context) hx.Request {
+	return hx.Request{
+		Boosted:               IsBoosted(ctx),
+		HistoryRestoreRequest: IsHistoryRestoreRequest(ctx),
+		Request:               IsHtmx(ctx),
+		Target:                GetTarget(ctx),
+	}
+}
@@ -0,0 +1,38 @@
+package hxgin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/stackus/hxgo"
+	"github.com/stackus/hxgo/hxtest"
+)
+
+func TestRequestConformance(t *testing.T) {
+	t.Parallel()
+
+	hxtest.RunRequestConformance(t, func(t *testing.T, headers map[string]string) hxtest.RequestGetters {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = r
+
+		return hxtest.RequestGetters{
+			IsBoosted:               func() bool { return IsBoosted(ctx) },
+			GetCurrentUrl:           func() string { return GetCurrentUrl(ctx) },
+			IsHistoryRestoreRequest: func() bool { return IsHistoryRestoreRequest(ctx) },
+			GetPrompt:               func() string { return GetPrompt(ctx) },
+			IsRequest:               func() bool { return IsRequest(ctx) },
+			IsHtmx:                  func() bool { return IsHtmx(ctx) },
+			GetTarget:               func() string { return GetTarget(ctx) },
+			GetTriggerName:          func() string { return GetTriggerName(ctx) },
+			GetTrigger:              func() string { return GetTrigger(ctx) },
+			ParseRequest:            func() hx.Request { return ParseRequest(ctx) },
+		}
+	})
+}
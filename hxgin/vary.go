@@ -0,0 +1,29 @@
+package hxgin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/stackus/hxgo"
+)
+
+const varyKey = "hxgo.vary"
+
+// VaryMiddleware adds the HTMX request headers that a handler reads to the Vary response header.
+//
+// See hx.VaryMiddleware for more details.
+//
+// Example usage:
+//
+//	r := gin.Default()
+//	r.Use(hxgin.VaryMiddleware)
+func VaryMiddleware(ctx *gin.Context) {
+	ctx.Set(varyKey, true)
+	ctx.Next()
+}
+
+// varyOn records the header in the Vary header of the response when VaryMiddleware is in use
+func varyOn(ctx *gin.Context, header string) {
+	if ctx.GetBool(varyKey) {
+		hx.AddVary(ctx.Writer.Header(), header)
+	}
+}
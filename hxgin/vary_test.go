@@ -0,0 +1,71 @@
+package hxgin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stackus/hxgo"
+)
+
+func TestVaryMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		handler gin.HandlerFunc
+		want    []string
+	}{
+		"No headers read": {
+			handler: func(ctx *gin.Context) {},
+			want:    nil,
+		},
+		"Read every header once": {
+			handler: func(ctx *gin.Context) {
+				_ = IsHtmx(ctx)
+				_ = IsHtmx(ctx)
+				_ = GetTarget(ctx)
+			},
+			want: []string{hx.HxRequest, hx.HxTarget},
+		},
+		"ParseRequest": {
+			handler: func(ctx *gin.Context) {
+				_ = ParseRequest(ctx)
+			},
+			want: []string{
+				hx.HxBoosted,
+				hx.HxHistoryRestoreRequest,
+				hx.HxRequest,
+				hx.HxTarget,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(VaryMiddleware)
+			r.GET("/", tt.handler)
+
+			wr := httptest.NewRecorder()
+			r.ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tt.want, wr.Header().Values("Vary"))
+		})
+	}
+}
+
+func TestVaryMiddleware_NotUsed(t *testing.T) {
+	t.Parallel()
+
+	r := gin.New()
+	r.GET("/", func(ctx *gin.Context) {
+		_ = ParseRequest(ctx)
+	})
+
+	wr := httptest.NewRecorder()
+	r.ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Empty(t, wr.Header().Values("Vary"))
+}
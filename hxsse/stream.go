@@ -0,0 +1,233 @@
+// Package hxsse streams Server-Sent Events to the htmx SSE extension.
+//
+// Events carrying HTML fragments can be swapped into the page using sse-swap, while
+// events created with hx.Event can be used to trigger client-side events using the
+// same definitions as the HX-Trigger response headers.
+//
+// More details: https://htmx.org/extensions/server-sent-events/
+package hxsse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stackus/hxgo"
+)
+
+// DefaultHeartbeat is the interval used to send heartbeat comments when no Heartbeat option is used.
+const DefaultHeartbeat = 15 * time.Second
+
+// Option is used to configure a Stream.
+type Option func(*Stream)
+
+// Heartbeat sets the interval used to send heartbeat comments to keep the connection open.
+//
+// A zero or negative interval disables the heartbeat.
+func Heartbeat(interval time.Duration) Option {
+	return func(s *Stream) { s.heartbeat = interval }
+}
+
+// Stream writes Server-Sent Events to an http.ResponseWriter.
+//
+// A Stream is safe for concurrent use. It is closed when the client disconnects,
+// which is detected using the context of the request, or when Close is called.
+//
+// Close must be called before the handler returns. The heartbeat keeps writing to the
+// http.ResponseWriter until the stream is closed, and the ResponseWriter must not be
+// used after the handler has returned.
+type Stream struct {
+	w         http.ResponseWriter
+	rc        *http.ResponseController
+	ctx       context.Context
+	cancel    context.CancelFunc
+	heartbeat time.Duration
+	mu        sync.Mutex
+	done      chan struct{}
+}
+
+// NewStream starts a Server-Sent Events response.
+//
+// The response headers are written and flushed immediately. An error is returned
+// if the http.ResponseWriter does not support flushing. Always defer Close once the
+// stream has been created.
+//
+// Example usage:
+//
+//	func MyHandler(w http.ResponseWriter, r *http.Request) {
+//		stream, err := hxsse.NewStream(w, r)
+//		if err != nil {
+//			// handle error
+//		}
+//		defer stream.Close()
+//
+//		for {
+//			select {
+//			case <-stream.Done():
+//				return
+//			case item := <-items:
+//				_ = stream.Send("item-added", hx.Template(tmpl, "item", item))
+//				_ = stream.Trigger(hx.Event("count-changed", len(items)))
+//			}
+//		}
+//	}
+func NewStream(w http.ResponseWriter, r *http.Request, options ...Option) (*Stream, error) {
+	ctx, cancel := context.WithCancel(r.Context())
+	s := &Stream{
+		w:         w,
+		rc:        http.NewResponseController(w),
+		ctx:       ctx,
+		cancel:    cancel,
+		heartbeat: DefaultHeartbeat,
+		done:      make(chan struct{}),
+	}
+	for _, option := range options {
+		option(s)
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if err := s.rc.Flush(); err != nil {
+		cancel()
+		return nil, fmt.Errorf("unable to flush the event stream: %w", err)
+	}
+
+	go s.run()
+
+	return s, nil
+}
+
+// Done returns a channel that is closed when the client disconnects or the stream is closed.
+func (s *Stream) Done() <-chan struct{} {
+	return s.ctx.Done()
+}
+
+// Close stops the stream and waits for the heartbeat to stop.
+//
+// It must be called before the handler returns and may be called more than once. Close does
+// not close the underlying connection; return from your handler to end the response.
+func (s *Stream) Close() {
+	s.cancel()
+	<-s.done
+}
+
+// Send sends a named event with an HTML fragment as the data.
+//
+// Use the name with sse-swap to swap the fragment into the page. An error is returned if the
+// name contains a line break.
+//
+// Example usage:
+//
+//	_ = stream.Send("item-added", hx.HTML("<li>New item</li>"))
+//	// event: item-added
+//	// data: <li>New item</li>
+func (s *Stream) Send(name string, fragment hx.Fragment) error {
+	var buf bytes.Buffer
+	if err := fragment.Render(s.ctx, &buf); err != nil {
+		return fmt.Errorf("unable to render event %q: %w", name, err)
+	}
+
+	return s.write(name, buf.String())
+}
+
+// Trigger sends each event with the JSON data in the same shape as the HX-Trigger header.
+//
+// Each event is sent using its event name, so it can be used with hx-trigger="sse:name".
+// An error is returned if a name contains a line break.
+//
+// Example usage:
+//
+//	_ = stream.Trigger(hx.Event("count-changed", 42))
+//	// event: count-changed
+//	// data: {"count-changed":42}
+func (s *Stream) Trigger(events ...hx.TriggerEvent) error {
+	for _, event := range events {
+		m := event()
+		names := make([]string, 0, len(m))
+		for name := range m {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			data, err := json.Marshal(map[string]any{name: m[name]})
+			if err != nil {
+				return fmt.Errorf("unable to marshal event %q: %w", name, err)
+			}
+			if err = s.write(name, string(data)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Stream) run() {
+	defer close(s.done)
+	if s.heartbeat <= 0 {
+		<-s.ctx.Done()
+		return
+	}
+
+	ticker := time.NewTicker(s.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.writeRaw(": heartbeat\n\n"); err != nil {
+				s.cancel()
+				return
+			}
+		}
+	}
+}
+
+// lineBreaks are the line endings of the event stream format
+var lineBreaks = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+
+func (s *Stream) write(name, data string) error {
+	if strings.ContainsAny(name, "\r\n") {
+		return fmt.Errorf("invalid event name %q: contains a line break", name)
+	}
+
+	var b strings.Builder
+	if name != "" {
+		b.WriteString("event: ")
+		b.WriteString(name)
+		b.WriteByte('\n')
+	}
+	for _, line := range strings.Split(lineBreaks.Replace(data), "\n") {
+		b.WriteString("data: ")
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	b.WriteByte('\n')
+
+	return s.writeRaw(b.String())
+}
+
+func (s *Stream) writeRaw(message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(s.w, message); err != nil {
+		return err
+	}
+
+	return s.rc.Flush()
+}
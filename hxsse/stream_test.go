@@ -0,0 +1,154 @@
+package hxsse
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stackus/hxgo"
+)
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		send    func(s *Stream) error
+		want    string
+		wantErr bool
+	}{
+		"Send fragment": {
+			send: func(s *Stream) error {
+				return s.Send("item", hx.HTML("<li>one</li>"))
+			},
+			want: "event: item\ndata: <li>one</li>\n\n",
+		},
+		"Send multiline fragment": {
+			send: func(s *Stream) error {
+				return s.Send("list", hx.HTML("<ul>\n<li>one</li>\n</ul>"))
+			},
+			want: "event: list\ndata: <ul>\ndata: <li>one</li>\ndata: </ul>\n\n",
+		},
+		"Send fragment with other line breaks": {
+			send: func(s *Stream) error {
+				return s.Send("list", hx.HTML("<ul>\r\n<li>one</li>\r<li>two</li>\n</ul>"))
+			},
+			want: "event: list\ndata: <ul>\ndata: <li>one</li>\ndata: <li>two</li>\ndata: </ul>\n\n",
+		},
+		"Send name with a line break": {
+			send: func(s *Stream) error {
+				return s.Send("item\ndata: injected", hx.HTML("x"))
+			},
+			wantErr: true,
+		},
+		"Trigger name with a carriage return": {
+			send: func(s *Stream) error {
+				return s.Trigger(hx.Event("count\revent: other", 1))
+			},
+			wantErr: true,
+		},
+		"Send fragment error": {
+			send: func(s *Stream) error {
+				return s.Send("item", hx.FragmentFunc(func(context.Context, io.Writer) error {
+					return errors.New("bad fragment")
+				}))
+			},
+			wantErr: true,
+		},
+		"Trigger events": {
+			send: func(s *Stream) error {
+				return s.Trigger(hx.Event("count", 42), hx.Event("refresh"))
+			},
+			want: "event: count\ndata: {\"count\":42}\n\nevent: refresh\ndata: {\"refresh\":null}\n\n",
+		},
+		"Trigger bad data": {
+			send: func(s *Stream) error {
+				return s.Trigger(hx.Event("bad", make(chan int)))
+			},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			wr := httptest.NewRecorder()
+			s, err := NewStream(wr, httptest.NewRequest(http.MethodGet, "/", nil), Heartbeat(0))
+			assert.NoError(t, err)
+
+			err = tt.send(s)
+			s.Close()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "text/event-stream", wr.Header().Get("Content-Type"))
+			assert.Equal(t, tt.want, wr.Body.String())
+		})
+	}
+}
+
+func TestStream_Heartbeat(t *testing.T) {
+	t.Parallel()
+
+	wr := httptest.NewRecorder()
+	s, err := NewStream(wr, httptest.NewRequest(http.MethodGet, "/", nil), Heartbeat(time.Millisecond))
+	assert.NoError(t, err)
+
+	time.Sleep(20 * time.Millisecond)
+	s.Close()
+
+	assert.True(t, strings.HasPrefix(wr.Body.String(), ": heartbeat\n\n"))
+}
+
+func TestStream_Close(t *testing.T) {
+	t.Parallel()
+
+	wr := httptest.NewRecorder()
+	s, err := NewStream(wr, httptest.NewRequest(http.MethodGet, "/", nil), Heartbeat(time.Millisecond))
+	assert.NoError(t, err)
+
+	time.Sleep(5 * time.Millisecond)
+	s.Close()
+	body := wr.Body.String()
+
+	time.Sleep(5 * time.Millisecond)
+	s.Close()
+
+	assert.Equal(t, body, wr.Body.String())
+	assert.ErrorIs(t, s.Send("item", hx.HTML("x")), context.Canceled)
+}
+
+func TestStream_Disconnect(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	s, err := NewStream(httptest.NewRecorder(), r)
+	assert.NoError(t, err)
+
+	cancel()
+	select {
+	case <-s.Done():
+	case <-time.After(time.Second):
+		t.Fatal("stream not done after the client disconnected")
+	}
+	assert.ErrorIs(t, s.Send("item", hx.HTML("x")), context.Canceled)
+	s.Close()
+}
+
+type noFlushWriter struct {
+	http.ResponseWriter
+}
+
+func TestNewStream_NoFlusher(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewStream(noFlushWriter{httptest.NewRecorder()}, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Error(t, err)
+}
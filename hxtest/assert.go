@@ -0,0 +1,196 @@
+package hxtest
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stackus/hxgo"
+)
+
+// AssertStatus asserts that the recorded response has the expected status code.
+func AssertStatus(t testing.TB, rec *httptest.ResponseRecorder, status int) bool {
+	t.Helper()
+	return assert.Equal(t, status, rec.Code, "unexpected status code")
+}
+
+// AssertPushUrl asserts that the HX-Push-Url header is set to the expected url.
+func AssertPushUrl(t testing.TB, rec *httptest.ResponseRecorder, url string) bool {
+	t.Helper()
+	return assertHeader(t, rec, hx.HxPushUrl, url)
+}
+
+// AssertRedirect asserts that the HX-Redirect header is set to the expected url.
+func AssertRedirect(t testing.TB, rec *httptest.ResponseRecorder, url string) bool {
+	t.Helper()
+	return assertHeader(t, rec, hx.HxRedirect, url)
+}
+
+// AssertRefresh asserts that the HX-Refresh header is set to "true".
+func AssertRefresh(t testing.TB, rec *httptest.ResponseRecorder) bool {
+	t.Helper()
+	return assertHeader(t, rec, hx.HxRefresh, "true")
+}
+
+// AssertReplaceUrl asserts that the HX-Replace-Url header is set to the expected url.
+func AssertReplaceUrl(t testing.TB, rec *httptest.ResponseRecorder, url string) bool {
+	t.Helper()
+	return assertHeader(t, rec, hx.HxReplaceUrl, url)
+}
+
+// AssertRetarget asserts that the HX-Retarget header is set to the expected selector.
+func AssertRetarget(t testing.TB, rec *httptest.ResponseRecorder, selector string) bool {
+	t.Helper()
+	return assertHeader(t, rec, hx.HxRetarget, selector)
+}
+
+// AssertReselect asserts that the HX-Reselect header is set to the expected selector.
+func AssertReselect(t testing.TB, rec *httptest.ResponseRecorder, selector string) bool {
+	t.Helper()
+	return assertHeader(t, rec, hx.HxReselect, selector)
+}
+
+// AssertReswap asserts that the HX-Reswap header matches the expected swap style and modifiers.
+//
+// Either a Reswap constant or a SwapSpec can be used. The modifiers are compared by value,
+// so the order in which they were added does not matter.
+//
+// Example usage:
+//
+//	hxtest.AssertReswap(t, rec, hx.SwapOuterHtml.Settle(time.Second))
+func AssertReswap[T hx.Reswap | hx.SwapSpec](t testing.TB, rec *httptest.ResponseRecorder, swap T) bool {
+	t.Helper()
+
+	var expected string
+	switch s := any(swap).(type) {
+	case hx.SwapSpec:
+		expected = s.String()
+	case hx.Reswap:
+		expected = string(s)
+	}
+	want, err := hx.ParseReswap(expected)
+	if !assert.NoError(t, err, "unable to parse expected reswap") {
+		return false
+	}
+	value := rec.Header().Get(hx.HxReswap)
+	if !assert.NotEmpty(t, value, "missing %s header", hx.HxReswap) {
+		return false
+	}
+	got, err := hx.ParseReswap(value)
+	if !assert.NoError(t, err, "unable to parse %s header", hx.HxReswap) {
+		return false
+	}
+
+	return assert.Equal(t, want, got, "unexpected %s header: %s", hx.HxReswap, value)
+}
+
+// AssertLocation asserts that the HX-Location header matches the expected path and properties.
+//
+// Example usage:
+//
+//	hxtest.AssertLocation(t, rec, "/items", hx.Target("#list"))
+func AssertLocation(t testing.TB, rec *httptest.ResponseRecorder, path string, properties ...hx.LocationProperty) bool {
+	t.Helper()
+
+	expected, err := hx.BuildResponse(hx.Location(path, properties...))
+	if !assert.NoError(t, err, "unable to build expected location") {
+		return false
+	}
+	want, err := hx.ParseLocation(expected.Headers()[hx.HxLocation])
+	if !assert.NoError(t, err, "unable to parse expected location") {
+		return false
+	}
+	value := rec.Header().Get(hx.HxLocation)
+	if !assert.NotEmpty(t, value, "missing %s header", hx.HxLocation) {
+		return false
+	}
+	got, err := hx.ParseLocation(value)
+	if !assert.NoError(t, err, "unable to parse %s header", hx.HxLocation) {
+		return false
+	}
+
+	return assert.Equal(t, want, got, "unexpected %s header: %s", hx.HxLocation, value)
+}
+
+// AssertTriggered asserts that the HX-Trigger header contains the named event with the expected data.
+//
+// The data is handled the same way as hx.Event: no data is expected to be null,
+// a single value is compared directly, and multiple values are compared as an array.
+//
+// Example usage:
+//
+//	hxtest.AssertTriggered(t, rec, "myEvent", map[string]any{"id": 1})
+func AssertTriggered(t testing.TB, rec *httptest.ResponseRecorder, name string, data ...any) bool {
+	t.Helper()
+	return assertTriggered(t, rec, hx.HxTrigger, name, data)
+}
+
+// AssertTriggeredAfterSettle asserts that the HX-Trigger-After-Settle header contains the named event with the expected data.
+//
+// See AssertTriggered for more details.
+func AssertTriggeredAfterSettle(t testing.TB, rec *httptest.ResponseRecorder, name string, data ...any) bool {
+	t.Helper()
+	return assertTriggered(t, rec, hx.HxTriggerAfterSettle, name, data)
+}
+
+// AssertTriggeredAfterSwap asserts that the HX-Trigger-After-Swap header contains the named event with the expected data.
+//
+// See AssertTriggered for more details.
+func AssertTriggeredAfterSwap(t testing.TB, rec *httptest.ResponseRecorder, name string, data ...any) bool {
+	t.Helper()
+	return assertTriggered(t, rec, hx.HxTriggerAfterSwap, name, data)
+}
+
+func assertHeader(t testing.TB, rec *httptest.ResponseRecorder, header, want string) bool {
+	t.Helper()
+	return assert.Equal(t, want, rec.Header().Get(header), "unexpected %s header", header)
+}
+
+func assertTriggered(t testing.TB, rec *httptest.ResponseRecorder, header, name string, data []any) bool {
+	t.Helper()
+
+	parsed, err := hx.ParseResponse(rec.Header())
+	if !assert.NoError(t, err, "unable to parse response headers") {
+		return false
+	}
+
+	var events []hx.ParsedEvent
+	switch header {
+	case hx.HxTriggerAfterSettle:
+		events = parsed.TriggerAfterSettle
+	case hx.HxTriggerAfterSwap:
+		events = parsed.TriggerAfterSwap
+	default:
+		events = parsed.Trigger
+	}
+
+	for _, event := range events {
+		if event.Name != name {
+			continue
+		}
+		want, err := json.Marshal(eventData(data))
+		if !assert.NoError(t, err, "unable to marshal expected event data") {
+			return false
+		}
+		got := []byte(event.Data)
+		if got == nil {
+			got = []byte("null")
+		}
+		return assert.JSONEq(t, string(want), string(got), "unexpected data for event %q in %s header", name, header)
+	}
+
+	return assert.Fail(t, "event not triggered", "event %q not found in %s header: %q", name, header, rec.Header().Get(header))
+}
+
+func eventData(data []any) any {
+	switch len(data) {
+	case 0:
+		return nil
+	case 1:
+		return data[0]
+	default:
+		return data
+	}
+}
@@ -0,0 +1,167 @@
+package hxtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stackus/hxgo"
+)
+
+// recordingT records failures instead of failing the test
+type recordingT struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recordingT) Helper()               {}
+func (r *recordingT) Errorf(string, ...any) { r.failed = true }
+
+func TestAssertions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		options  []hx.ResponseOption
+		assert   func(t testing.TB, rec *httptest.ResponseRecorder) bool
+		wantFail bool
+	}{
+		"Status": {
+			options: []hx.ResponseOption{hx.StatusStopPolling},
+			assert: func(t testing.TB, rec *httptest.ResponseRecorder) bool {
+				return AssertStatus(t, rec, int(hx.StatusStopPolling))
+			},
+		},
+		"Retarget": {
+			options: []hx.ResponseOption{hx.Retarget("#foo")},
+			assert: func(t testing.TB, rec *httptest.ResponseRecorder) bool {
+				return AssertRetarget(t, rec, "#foo")
+			},
+		},
+		"Retarget mismatch": {
+			options: []hx.ResponseOption{hx.Retarget("#foo")},
+			assert: func(t testing.TB, rec *httptest.ResponseRecorder) bool {
+				return AssertRetarget(t, rec, "#bar")
+			},
+			wantFail: true,
+		},
+		"Reswap modifiers in any order": {
+			options: []hx.ResponseOption{hx.SwapOuterHtml.Swap(time.Second).Settle(2 * time.Second)},
+			assert: func(t testing.TB, rec *httptest.ResponseRecorder) bool {
+				return AssertReswap(t, rec, hx.SwapOuterHtml.Settle(2*time.Second).Swap(time.Second))
+			},
+		},
+		"Reswap spec": {
+			options: []hx.ResponseOption{hx.SwapOuterHtml.Swap(time.Second).Settle(2 * time.Second)},
+			assert: func(t testing.TB, rec *httptest.ResponseRecorder) bool {
+				return AssertReswap(t, rec, hx.NewSwapSpec(hx.SwapOuterHtml).Settle(2*time.Second).Swap(time.Second))
+			},
+		},
+		"Reswap mismatch": {
+			options: []hx.ResponseOption{hx.SwapOuterHtml.Settle(time.Second)},
+			assert: func(t testing.TB, rec *httptest.ResponseRecorder) bool {
+				return AssertReswap(t, rec, hx.SwapOuterHtml)
+			},
+			wantFail: true,
+		},
+		"Reswap missing": {
+			options: []hx.ResponseOption{},
+			assert: func(t testing.TB, rec *httptest.ResponseRecorder) bool {
+				return AssertReswap(t, rec, hx.SwapOuterHtml)
+			},
+			wantFail: true,
+		},
+		"Location path": {
+			options: []hx.ResponseOption{hx.Location("/foo")},
+			assert: func(t testing.TB, rec *httptest.ResponseRecorder) bool {
+				return AssertLocation(t, rec, "/foo")
+			},
+		},
+		"Location properties": {
+			options: []hx.ResponseOption{hx.Location("/foo", hx.Target("#bar"), hx.Values(map[string]int{"k": 1}))},
+			assert: func(t testing.TB, rec *httptest.ResponseRecorder) bool {
+				return AssertLocation(t, rec, "/foo", hx.Values(map[string]int{"k": 1}), hx.Target("#bar"))
+			},
+		},
+		"Location mismatch": {
+			options: []hx.ResponseOption{hx.Location("/foo", hx.Target("#bar"))},
+			assert: func(t testing.TB, rec *httptest.ResponseRecorder) bool {
+				return AssertLocation(t, rec, "/foo")
+			},
+			wantFail: true,
+		},
+		"Triggered": {
+			options: []hx.ResponseOption{hx.Trigger(hx.Event("myEvent", map[string]any{"k": "v"}), hx.Event("other"))},
+			assert: func(t testing.TB, rec *httptest.ResponseRecorder) bool {
+				return AssertTriggered(t, rec, "myEvent", map[string]string{"k": "v"}) &&
+					AssertTriggered(t, rec, "other")
+			},
+		},
+		"Triggered with multiple values": {
+			options: []hx.ResponseOption{hx.Trigger(hx.Event("myEvent", "a", "b"))},
+			assert: func(t testing.TB, rec *httptest.ResponseRecorder) bool {
+				return AssertTriggered(t, rec, "myEvent", "a", "b")
+			},
+		},
+		"Triggered with wrong data": {
+			options: []hx.ResponseOption{hx.Trigger(hx.Event("myEvent", "a"))},
+			assert: func(t testing.TB, rec *httptest.ResponseRecorder) bool {
+				return AssertTriggered(t, rec, "myEvent", "b")
+			},
+			wantFail: true,
+		},
+		"Not triggered": {
+			options: []hx.ResponseOption{hx.Trigger(hx.Event("myEvent"))},
+			assert: func(t testing.TB, rec *httptest.ResponseRecorder) bool {
+				return AssertTriggered(t, rec, "otherEvent")
+			},
+			wantFail: true,
+		},
+		"Triggered after settle": {
+			options: []hx.ResponseOption{hx.TriggerAfterSettle(hx.Event("myEvent", 1))},
+			assert: func(t testing.TB, rec *httptest.ResponseRecorder) bool {
+				return AssertTriggeredAfterSettle(t, rec, "myEvent", 1)
+			},
+		},
+		"Triggered on the wrong header": {
+			options: []hx.ResponseOption{hx.TriggerAfterSettle(hx.Event("myEvent", 1))},
+			assert: func(t testing.TB, rec *httptest.ResponseRecorder) bool {
+				return AssertTriggered(t, rec, "myEvent", 1)
+			},
+			wantFail: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			assert.NoError(t, hx.Response(rec, tc.options...))
+
+			rt := &recordingT{TB: t}
+			ok := tc.assert(rt, rec)
+
+			assert.Equal(t, tc.wantFail, rt.failed)
+			assert.Equal(t, !tc.wantFail, ok)
+		})
+	}
+}
+
+func TestAssertions_Handler(t *testing.T) {
+	t.Parallel()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hx.GetTarget(r) != "list" {
+			return
+		}
+		_ = hx.Response(w, hx.Retarget("#items"), hx.SwapBeforeEnd, hx.Trigger(hx.Event("loaded", hx.GetTrigger(r))))
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, NewRequest(http.MethodGet, "/items").Target("list").Trigger("btn").Request())
+
+	AssertStatus(t, rec, http.StatusOK)
+	AssertRetarget(t, rec, "#items")
+	AssertReswap(t, rec, hx.SwapBeforeEnd)
+	AssertTriggered(t, rec, "loaded", "btn")
+}
@@ -0,0 +1,218 @@
+package hxtest
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stackus/hxgo"
+)
+
+// RequestGetters are the request helpers of an adapter bound to a single request.
+type RequestGetters struct {
+	IsBoosted               func() bool
+	GetCurrentUrl           func() string
+	IsHistoryRestoreRequest func() bool
+	GetPrompt               func() string
+	IsRequest               func() bool
+	IsHtmx                  func() bool
+	GetTarget               func() string
+	GetTriggerName          func() string
+	GetTrigger              func() string
+	ParseRequest            func() hx.Request
+}
+
+var requestCases = map[string]struct {
+	headers map[string]string
+	want    hx.Request
+}{
+	"No headers": {
+		headers: map[string]string{},
+		want:    hx.Request{},
+	},
+	"Htmx request": {
+		headers: map[string]string{hx.HxRequest: "true"},
+		want:    hx.Request{Request: true},
+	},
+	"Htmx request set to false": {
+		headers: map[string]string{hx.HxRequest: "false"},
+		want:    hx.Request{},
+	},
+	"Htmx request set to FALSE": {
+		headers: map[string]string{hx.HxRequest: "FALSE"},
+		want:    hx.Request{},
+	},
+	"Boosted": {
+		headers: map[string]string{hx.HxRequest: "true", hx.HxBoosted: "true"},
+		want:    hx.Request{Request: true, Boosted: true},
+	},
+	"Boosted with any value": {
+		headers: map[string]string{hx.HxBoosted: "1"},
+		want:    hx.Request{Boosted: true},
+	},
+	"Boosted set to false": {
+		headers: map[string]string{hx.HxBoosted: "false"},
+		want:    hx.Request{},
+	},
+	"History restore request": {
+		headers: map[string]string{hx.HxHistoryRestoreRequest: "true"},
+		want:    hx.Request{HistoryRestoreRequest: true},
+	},
+	"History restore request set to false": {
+		headers: map[string]string{hx.HxHistoryRestoreRequest: "false"},
+		want:    hx.Request{},
+	},
+	"String headers": {
+		headers: map[string]string{
+			hx.HxRequest:     "true",
+			hx.HxCurrentUrl:  "http://localhost/foo?bar=baz",
+			hx.HxPrompt:      "yes",
+			hx.HxTarget:      "main",
+			hx.HxTrigger:     "button",
+			hx.HxTriggerName: "save",
+		},
+		want: hx.Request{
+			Request:     true,
+			CurrentUrl:  &url.URL{Scheme: "http", Host: "localhost", Path: "/foo", RawQuery: "bar=baz"},
+			Prompt:      "yes",
+			Target:      "main",
+			Trigger:     "button",
+			TriggerName: "save",
+		},
+	},
+	"Version": {
+		headers: map[string]string{hx.HxRequest: "true", hx.VersionHeader: "2.0.4"},
+		want:    hx.Request{Request: true, Version: hx.Version2},
+	},
+	"Lowercase header names": {
+		headers: map[string]string{"hx-request": "true", "hx-target": "main"},
+		want:    hx.Request{Request: true, Target: "main"},
+	},
+}
+
+// RunRequestConformance checks that the request helpers of an adapter read the HTMX request headers
+// the same way as the hx package.
+//
+// The newGetters function creates a request with the headers and returns the request helpers for it.
+//
+// Example usage:
+//
+//	func TestRequestConformance(t *testing.T) {
+//		hxtest.RunRequestConformance(t, func(t *testing.T, headers map[string]string) hxtest.RequestGetters {
+//			ctx := newContext(headers)
+//			return hxtest.RequestGetters{
+//				IsBoosted: func() bool { return mylib.IsBoosted(ctx) },
+//				// ...
+//			}
+//		})
+//	}
+func RunRequestConformance(t *testing.T, newGetters func(t *testing.T, headers map[string]string) RequestGetters) {
+	t.Helper()
+
+	for name, tc := range requestCases {
+		t.Run(name, func(t *testing.T) {
+			g := newGetters(t, tc.headers)
+
+			assert.Equal(t, tc.want.Boosted, g.IsBoosted(), "IsBoosted")
+			assert.Equal(t, currentUrl(tc.want.CurrentUrl), g.GetCurrentUrl(), "GetCurrentUrl")
+			assert.Equal(t, tc.want.HistoryRestoreRequest, g.IsHistoryRestoreRequest(), "IsHistoryRestoreRequest")
+			assert.Equal(t, tc.want.Prompt, g.GetPrompt(), "GetPrompt")
+			assert.Equal(t, tc.want.Request, g.IsRequest(), "IsRequest")
+			assert.Equal(t, tc.want.Request, g.IsHtmx(), "IsHtmx")
+			assert.Equal(t, tc.want.Target, g.GetTarget(), "GetTarget")
+			assert.Equal(t, tc.want.TriggerName, g.GetTriggerName(), "GetTriggerName")
+			assert.Equal(t, tc.want.Trigger, g.GetTrigger(), "GetTrigger")
+			assert.Equal(t, tc.want, g.ParseRequest(), "ParseRequest")
+		})
+	}
+}
+
+func currentUrl(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	return u.String()
+}
+
+// AdapterResult returns the response headers and status code that have been set using an adapter.
+type AdapterResult func() (http.Header, int)
+
+// RunAdapterConformance checks that an hx.Adapter reads the HTMX request headers the same way
+// as the hx package, and that hx.ResponseFor sets the headers and status code through it.
+//
+// The newAdapter function creates a request with the headers and returns the adapter for it, along
+// with a function to fetch the response headers and status code that were set. Merging trigger
+// events is only checked when the adapter implements hx.ResponseHeaderAdapter.
+//
+// Example usage:
+//
+//	func TestAdapterConformance(t *testing.T) {
+//		hxtest.RunAdapterConformance(t, func(t *testing.T, headers map[string]string) (hx.Adapter, hxtest.AdapterResult) {
+//			ctx := newContext(headers)
+//			return adapter{ctx}, func() (http.Header, int) {
+//				return ctx.ResponseHeaders(), ctx.StatusCode()
+//			}
+//		})
+//	}
+func RunAdapterConformance(t *testing.T, newAdapter func(t *testing.T, headers map[string]string) (hx.Adapter, AdapterResult)) {
+	t.Helper()
+
+	t.Run("Request", func(t *testing.T) {
+		RunRequestConformance(t, func(t *testing.T, headers map[string]string) RequestGetters {
+			a, _ := newAdapter(t, headers)
+			req := hx.ReadRequest(a)
+			return RequestGetters{
+				IsBoosted:               req.IsBoosted,
+				GetCurrentUrl:           req.GetCurrentUrl,
+				IsHistoryRestoreRequest: req.IsHistoryRestoreRequest,
+				GetPrompt:               req.GetPrompt,
+				IsRequest:               req.IsRequest,
+				IsHtmx:                  req.IsHtmx,
+				GetTarget:               req.GetTarget,
+				GetTriggerName:          req.GetTriggerName,
+				GetTrigger:              req.GetTrigger,
+				ParseRequest:            req.Parse,
+			}
+		})
+	})
+
+	t.Run("Response headers", func(t *testing.T) {
+		a, result := newAdapter(t, nil)
+		_, err := hx.ResponseFor(a, hx.Retarget("#main"), hx.PushUrl("/items"))
+		if !assert.NoError(t, err) {
+			return
+		}
+		h, _ := result()
+		assert.Equal(t, "#main", h.Get(hx.HxRetarget))
+		assert.Equal(t, "/items", h.Get(hx.HxPushUrl))
+	})
+
+	t.Run("Response status", func(t *testing.T) {
+		a, result := newAdapter(t, nil)
+		_, err := hx.ResponseFor(a, hx.StatusStopPolling)
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, status := result()
+		assert.Equal(t, int(hx.StatusStopPolling), status)
+	})
+
+	t.Run("Merge triggers", func(t *testing.T) {
+		a, result := newAdapter(t, nil)
+		if _, ok := a.(hx.ResponseHeaderAdapter); !ok {
+			t.Skip("adapter does not implement hx.ResponseHeaderAdapter")
+		}
+		_, err := hx.ResponseFor(a, hx.Trigger(hx.Event("a")))
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, err = hx.ResponseFor(a, hx.Trigger(hx.Event("b", 1)))
+		if !assert.NoError(t, err) {
+			return
+		}
+		h, _ := result()
+		assert.JSONEq(t, `{"a":null,"b":1}`, h.Get(hx.HxTrigger))
+	})
+}
@@ -0,0 +1,107 @@
+// Package hxtest provides helpers for testing handlers that work with HTMX requests and responses.
+//
+// Build HTMX requests with NewRequest and check the recorded responses with the Assert* functions:
+//
+//	req := hxtest.NewRequest(http.MethodGet, "/items").Target("list").Trigger("load-more").Request()
+//	rec := httptest.NewRecorder()
+//	handler.ServeHTTP(rec, req)
+//
+//	hxtest.AssertReswap(t, rec, hx.SwapBeforeEnd)
+//	hxtest.AssertTriggered(t, rec, "items-loaded", 10)
+package hxtest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/stackus/hxgo"
+)
+
+// RequestBuilder builds an *http.Request with HTMX request headers.
+//
+// Use NewRequest to create a new RequestBuilder.
+type RequestBuilder struct {
+	r *http.Request
+}
+
+// NewRequest creates a new RequestBuilder for an HTMX request.
+//
+// The HX-Request header is always set to "true". The request is created using
+// httptest.NewRequest and is only suitable for use in tests.
+//
+// Example usage:
+//
+//	req := hxtest.NewRequest(http.MethodGet, "/x").Boosted().Target("list").Request()
+func NewRequest(method, target string) *RequestBuilder {
+	r := httptest.NewRequest(method, target, nil)
+	r.Header.Set(hx.HxRequest, "true")
+
+	return &RequestBuilder{r: r}
+}
+
+// Boosted sets the HX-Boosted header to "true".
+func (b *RequestBuilder) Boosted() *RequestBuilder {
+	return b.Header(hx.HxBoosted, "true")
+}
+
+// CurrentUrl sets the HX-Current-URL header.
+func (b *RequestBuilder) CurrentUrl(currentUrl string) *RequestBuilder {
+	return b.Header(hx.HxCurrentUrl, currentUrl)
+}
+
+// HistoryRestoreRequest sets the HX-History-Restore-Request header to "true".
+func (b *RequestBuilder) HistoryRestoreRequest() *RequestBuilder {
+	return b.Header(hx.HxHistoryRestoreRequest, "true")
+}
+
+// Prompt sets the HX-Prompt header.
+func (b *RequestBuilder) Prompt(prompt string) *RequestBuilder {
+	return b.Header(hx.HxPrompt, prompt)
+}
+
+// Target sets the HX-Target header.
+func (b *RequestBuilder) Target(target string) *RequestBuilder {
+	return b.Header(hx.HxTarget, target)
+}
+
+// Trigger sets the HX-Trigger header.
+func (b *RequestBuilder) Trigger(trigger string) *RequestBuilder {
+	return b.Header(hx.HxTrigger, trigger)
+}
+
+// TriggerName sets the HX-Trigger-Name header.
+func (b *RequestBuilder) TriggerName(triggerName string) *RequestBuilder {
+	return b.Header(hx.HxTriggerName, triggerName)
+}
+
+// Header sets any other header on the request.
+func (b *RequestBuilder) Header(key, value string) *RequestBuilder {
+	b.r.Header.Set(key, value)
+	return b
+}
+
+// Form sets the body of the request to the URL encoded values.
+//
+// The Content-Type header is set to "application/x-www-form-urlencoded".
+func (b *RequestBuilder) Form(values url.Values) *RequestBuilder {
+	b.Header("Content-Type", "application/x-www-form-urlencoded")
+	return b.Body(strings.NewReader(values.Encode()))
+}
+
+// Body sets the body of the request.
+func (b *RequestBuilder) Body(body io.Reader) *RequestBuilder {
+	b.r.Body = io.NopCloser(body)
+	b.r.ContentLength = -1
+	if l, ok := body.(interface{ Len() int }); ok {
+		b.r.ContentLength = int64(l.Len())
+	}
+	return b
+}
+
+// Request returns the built *http.Request.
+func (b *RequestBuilder) Request() *http.Request {
+	return b.r
+}
@@ -0,0 +1,65 @@
+package hxtest
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stackus/hxgo"
+)
+
+func TestNewRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		builder *RequestBuilder
+		want    http.Header
+	}{
+		"Request only": {
+			builder: NewRequest(http.MethodGet, "/foo"),
+			want: http.Header{
+				hx.HxRequest: []string{"true"},
+			},
+		},
+		"Every header": {
+			builder: NewRequest(http.MethodGet, "/foo").
+				Boosted().
+				CurrentUrl("http://localhost/bar").
+				HistoryRestoreRequest().
+				Prompt("yes").
+				Target("list").
+				Trigger("btn").
+				TriggerName("button"),
+			want: http.Header{
+				hx.HxRequest:               []string{"true"},
+				hx.HxBoosted:               []string{"true"},
+				hx.HxCurrentUrl:            []string{"http://localhost/bar"},
+				hx.HxHistoryRestoreRequest: []string{"true"},
+				hx.HxPrompt:                []string{"yes"},
+				hx.HxTarget:                []string{"list"},
+				"Hx-Trigger":               []string{"btn"},
+				hx.HxTriggerName:           []string{"button"},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := tc.builder.Request()
+
+			assert.Equal(t, tc.want, r.Header)
+			assert.True(t, hx.IsHtmx(r))
+		})
+	}
+}
+
+func TestRequestBuilder_Form(t *testing.T) {
+	t.Parallel()
+
+	r := NewRequest(http.MethodPost, "/foo").Form(url.Values{"k": []string{"v"}}).Request()
+
+	assert.Equal(t, int64(3), r.ContentLength)
+	assert.NoError(t, r.ParseForm())
+	assert.Equal(t, "v", r.PostForm.Get("k"))
+}
@@ -0,0 +1,136 @@
+// Package hxws decodes and encodes messages for the htmx WebSocket extension.
+//
+// Incoming messages contain the form values of the triggering element along with
+// a HEADERS object carrying the HTMX request headers. Outgoing messages are HTML
+// fragments that htmx swaps into the page out-of-band.
+//
+// The package does not depend on a WebSocket implementation; wrap your connection
+// in the small Conn interface to use it.
+//
+// More details: https://htmx.org/extensions/web-sockets/
+package hxws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/stackus/hxgo"
+)
+
+// Conn is a message based connection such as a WebSocket.
+type Conn interface {
+	// ReadMessage blocks until the next message is received.
+	ReadMessage(ctx context.Context) ([]byte, error)
+	// WriteMessage sends a single message.
+	WriteMessage(ctx context.Context, data []byte) error
+}
+
+// Message is a decoded message sent by the htmx WebSocket extension.
+type Message struct {
+	// Request contains the HTMX headers sent with the message.
+	Request hx.Request
+	// Values contains the form values of the message without the HEADERS object.
+	Values map[string]any
+}
+
+// Form returns the string values of the message as url.Values.
+//
+// Values that are neither strings nor arrays of strings are skipped.
+func (m Message) Form() url.Values {
+	form := make(url.Values, len(m.Values))
+	for key, value := range m.Values {
+		switch v := value.(type) {
+		case string:
+			form.Add(key, v)
+		case []any:
+			for _, item := range v {
+				if s, ok := item.(string); ok {
+					form.Add(key, s)
+				}
+			}
+		}
+	}
+
+	return form
+}
+
+// Decode decodes a message sent by the htmx WebSocket extension.
+//
+// Example usage:
+//
+//	msg, err := hxws.Decode(data)
+//	// handle err
+//	if msg.Request.Target == "chat" {
+//		// do something with msg.Form().Get("message")
+//	}
+func Decode(data []byte) (*Message, error) {
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("unable to decode message: %w", err)
+	}
+
+	h := http.Header{}
+	if raw, ok := values["HEADERS"]; ok {
+		headers, ok := raw.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unable to decode message: HEADERS is not an object")
+		}
+		for key, value := range headers {
+			if s, ok := value.(string); ok {
+				h.Set(key, s)
+			}
+		}
+		delete(values, "HEADERS")
+	}
+
+	return &Message{
+		Request: hx.ParseHeader(h),
+		Values:  values,
+	}, nil
+}
+
+// Encode renders the fragments into a single message.
+//
+// Every element in the message is swapped out-of-band by htmx; use hx.OOBSwap to
+// choose the swap style and target of each fragment.
+func Encode(ctx context.Context, fragments ...hx.Fragment) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, fragment := range fragments {
+		if err := fragment.Render(ctx, &buf); err != nil {
+			return nil, fmt.Errorf("unable to encode message: %w", err)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Read reads and decodes the next message from the connection.
+func Read(ctx context.Context, conn Conn) (*Message, error) {
+	data, err := conn.ReadMessage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode(data)
+}
+
+// Write renders the fragments and sends them to the connection as a single message.
+//
+// Example usage:
+//
+//	err := hxws.Write(ctx, conn,
+//		hx.OOBSwap(hx.SwapBeforeEnd, "#messages", hx.Template(tmpl, "message", msg)),
+//		hx.OOBSwap(hx.SwapOuterHtml, "#chat-form", hx.Template(tmpl, "form", nil)),
+//	)
+func Write(ctx context.Context, conn Conn, fragments ...hx.Fragment) error {
+	data, err := Encode(ctx, fragments...)
+	if err != nil {
+		return err
+	}
+
+	return conn.WriteMessage(ctx, data)
+}
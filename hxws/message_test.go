@@ -0,0 +1,112 @@
+package hxws
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stackus/hxgo"
+)
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		data    string
+		want    *Message
+		wantErr bool
+	}{
+		"Message with headers": {
+			data: `{"message":"hello","HEADERS":{"HX-Request":"true","HX-Trigger":"chat-form","HX-Trigger-Name":null,"HX-Target":"chat","HX-Current-URL":"http://localhost/chat"}}`,
+			want: &Message{
+				Request: hx.Request{
+					Request:    true,
+					Trigger:    "chat-form",
+					Target:     "chat",
+					CurrentUrl: &url.URL{Scheme: "http", Host: "localhost", Path: "/chat"},
+				},
+				Values: map[string]any{"message": "hello"},
+			},
+		},
+		"Message without headers": {
+			data: `{"message":"hello"}`,
+			want: &Message{
+				Values: map[string]any{"message": "hello"},
+			},
+		},
+		"Bad headers": {
+			data:    `{"HEADERS":"nope"}`,
+			wantErr: true,
+		},
+		"Bad JSON": {
+			data:    `{"message":`,
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Decode([]byte(tt.data))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMessage_Form(t *testing.T) {
+	t.Parallel()
+
+	msg := Message{Values: map[string]any{
+		"name":  "foo",
+		"tags":  []any{"a", "b"},
+		"count": 1.0,
+	}}
+
+	assert.Equal(t, url.Values{"name": {"foo"}, "tags": {"a", "b"}}, msg.Form())
+}
+
+func TestReadWrite(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, server := Pipe()
+	defer client.Close()
+
+	go func() {
+		_ = client.WriteMessage(ctx, []byte(`{"message":"hello","HEADERS":{"HX-Request":"true","HX-Target":"chat"}}`))
+	}()
+
+	msg, err := Read(ctx, server)
+	assert.NoError(t, err)
+	assert.Equal(t, "chat", msg.Request.Target)
+	assert.Equal(t, "hello", msg.Form().Get("message"))
+
+	go func() {
+		_ = Write(ctx, server,
+			hx.OOBSwap(hx.SwapBeforeEnd, "#messages", hx.HTML("<p>hello</p>")),
+			hx.HTML(`<form id="chat-form"></form>`),
+		)
+	}()
+
+	data, err := client.ReadMessage(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, `<div hx-swap-oob="beforeend:#messages"><p>hello</p></div><form id="chat-form"></form>`, string(data))
+}
+
+func TestPipe_Close(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, server := Pipe()
+	assert.NoError(t, client.Close())
+	assert.NoError(t, server.Close())
+
+	_, err := server.ReadMessage(ctx)
+	assert.Error(t, err)
+	assert.Error(t, client.WriteMessage(ctx, []byte("x")))
+}
@@ -0,0 +1,66 @@
+package hxws
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// PipeConn is one end of an in-memory connection created by Pipe.
+type PipeConn struct {
+	in     <-chan []byte
+	out    chan<- []byte
+	closed chan struct{}
+	once   *sync.Once
+}
+
+// Pipe creates a synchronous, in-memory connection.
+//
+// Messages written to one end are read from the other. It is useful for testing
+// code that uses a Conn without a real WebSocket.
+func Pipe() (*PipeConn, *PipeConn) {
+	a := make(chan []byte)
+	b := make(chan []byte)
+	closed := make(chan struct{})
+	once := &sync.Once{}
+
+	return &PipeConn{in: a, out: b, closed: closed, once: once},
+		&PipeConn{in: b, out: a, closed: closed, once: once}
+}
+
+// ReadMessage blocks until a message is written to the other end of the pipe.
+//
+// io.EOF is returned after the pipe has been closed.
+func (p *PipeConn) ReadMessage(ctx context.Context) ([]byte, error) {
+	select {
+	case data := <-p.in:
+		return data, nil
+	case <-p.closed:
+		return nil, io.EOF
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// WriteMessage blocks until the message is read from the other end of the pipe.
+//
+// io.ErrClosedPipe is returned after the pipe has been closed.
+func (p *PipeConn) WriteMessage(ctx context.Context, data []byte) error {
+	msg := make([]byte, len(data))
+	copy(msg, data)
+
+	select {
+	case p.out <- msg:
+		return nil
+	case <-p.closed:
+		return io.ErrClosedPipe
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close closes both ends of the pipe.
+func (p *PipeConn) Close() error {
+	p.once.Do(func() { close(p.closed) })
+	return nil
+}
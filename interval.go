@@ -0,0 +1,70 @@
+package hx
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// formatInterval encodes a duration of the swap and trigger modifiers in a form that htmx can parse
+//
+// The duration is rounded to whole milliseconds and written in seconds when there is no
+// remainder, so 90*time.Second is "90s" and 1500*time.Millisecond is "1500ms".
+func formatInterval(d time.Duration) string {
+	ms := d.Round(time.Millisecond).Milliseconds()
+	if ms != 0 && ms%1000 == 0 {
+		return strconv.FormatInt(ms/1000, 10) + "s"
+	}
+	return strconv.FormatInt(ms, 10) + "ms"
+}
+
+// checkIntervals rejects the durations that would be rounded by formatInterval
+func checkIntervals(durations ...*time.Duration) error {
+	for _, d := range durations {
+		if d != nil && *d%time.Millisecond != 0 {
+			return fmt.Errorf("duration %s is not a whole number of milliseconds", *d)
+		}
+	}
+	return nil
+}
+
+// parseInterval decodes the intervals that htmx accepts: plain milliseconds, or a number
+// with the "ms", "s", or "m" unit
+//
+// Outside of strict mode Go duration strings such as "1m30s" are also accepted; strict
+// mode rejects them, along with negative durations, because htmx cannot read them.
+func parseInterval(value string, strict bool) (time.Duration, error) {
+	number, unit := value, time.Millisecond
+	switch {
+	case strings.HasSuffix(value, "ms"):
+		number = strings.TrimSuffix(value, "ms")
+	case strings.HasSuffix(value, "s"):
+		number, unit = strings.TrimSuffix(value, "s"), time.Second
+	case strings.HasSuffix(value, "m"):
+		number, unit = strings.TrimSuffix(value, "m"), time.Minute
+	}
+
+	var dur time.Duration
+	if f, err := strconv.ParseFloat(number, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		dur = time.Duration(math.Round(f * float64(unit)))
+	} else if strict {
+		return 0, fmt.Errorf("invalid interval %q", value)
+	} else if dur, err = time.ParseDuration(value); err != nil {
+		return 0, err
+	}
+	if strict && dur < 0 {
+		return 0, fmt.Errorf("negative duration")
+	}
+
+	return dur, nil
+}
+
+func parseIntervalPtr(value string, strict bool) (*time.Duration, error) {
+	dur, err := parseInterval(value, strict)
+	if err != nil {
+		return nil, err
+	}
+	return &dur, nil
+}
@@ -0,0 +1,97 @@
+package hx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatInterval(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		dur  time.Duration
+		want string
+	}{
+		"Zero":                      {dur: 0, want: "0ms"},
+		"Milliseconds":              {dur: 250 * time.Millisecond, want: "250ms"},
+		"Seconds":                   {dur: 2 * time.Second, want: "2s"},
+		"Minutes":                   {dur: 90 * time.Second, want: "90s"},
+		"Fractional seconds":        {dur: 1500 * time.Millisecond, want: "1500ms"},
+		"Sub-millisecond rounds up": {dur: 1500 * time.Microsecond, want: "2ms"},
+		"Sub-millisecond rounds down": {
+			dur:  999*time.Millisecond + 400*time.Microsecond,
+			want: "999ms",
+		},
+		"Rounds to seconds": {dur: time.Second - 100*time.Microsecond, want: "1s"},
+		"Negative":          {dur: -time.Second, want: "-1s"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := formatInterval(tt.dur)
+			assert.Equal(t, tt.want, got)
+
+			parsed, err := parseInterval(got, false)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.dur.Round(time.Millisecond), parsed)
+		})
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value   string
+		strict  bool
+		want    time.Duration
+		wantErr bool
+	}{
+		"Plain milliseconds":      {value: "500", want: 500 * time.Millisecond},
+		"Milliseconds":            {value: "500ms", want: 500 * time.Millisecond},
+		"Seconds":                 {value: "2s", want: 2 * time.Second},
+		"Fractional seconds":      {value: "1.5s", want: 1500 * time.Millisecond},
+		"Minutes":                 {value: "2m", want: 2 * time.Minute},
+		"Go duration":             {value: "1m30s", want: 90 * time.Second},
+		"Strict go duration":      {value: "1m30s", strict: true, wantErr: true},
+		"Strict negative":         {value: "-1s", strict: true, wantErr: true},
+		"Strict seconds":          {value: "90s", strict: true, want: 90 * time.Second},
+		"Invalid":                 {value: "soon", wantErr: true},
+		"Strict invalid":          {value: "soon", strict: true, wantErr: true},
+		"Negative outside strict": {value: "-1s", want: -time.Second},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseInterval(tt.value, tt.strict)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSpecs_ValidateIntervals(t *testing.T) {
+	t.Parallel()
+
+	assert.Error(t, NewSwapSpec(SwapInnerHtml).Swap(1500*time.Microsecond).Validate())
+	assert.NoError(t, NewSwapSpec(SwapInnerHtml).Swap(1500*time.Millisecond).Validate())
+	assert.Error(t, NewTriggerSpec("keyup").Delay(time.Microsecond).Validate())
+	assert.Error(t, Every(time.Nanosecond).Validate())
+	assert.NoError(t, Every(90*time.Second).Validate())
+	assert.Error(t, Reswap("innerHTML swap:1m30s").Validate())
+}
+
+func TestReswap_NegativeDurations(t *testing.T) {
+	t.Parallel()
+
+	swap := SwapInnerHtml.Swap(-time.Second).Settle(-time.Millisecond)
+	assert.Equal(t, Reswap("innerHTML swap:-1s settle:-1ms"), swap)
+	assert.Error(t, swap.Validate())
+
+	_, err := BuildResponse(Strict(), SwapInnerHtml.Settle(-time.Second))
+	assert.EqualError(t, err, `Hx-Reswap: invalid swap "innerHTML settle:-1s": modifier "settle:-1s": negative duration`)
+}
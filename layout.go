@@ -0,0 +1,182 @@
+package hx
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Layout wraps the content of a page in the full page layout.
+//
+// Example usage:
+//
+//	layout := func(content hx.Fragment) hx.Fragment {
+//		return hx.FragmentFunc(func(ctx context.Context, w io.Writer) error {
+//			_, _ = io.WriteString(w, "<html><body>")
+//			if err := content.Render(ctx, w); err != nil {
+//				return err
+//			}
+//			_, err := io.WriteString(w, "</body></html>")
+//			return err
+//		})
+//	}
+type Layout func(content Fragment) Fragment
+
+// RenderMode overrides the choice between rendering the full page or only the content.
+type RenderMode int
+
+// RenderMode constants
+const (
+	// RenderAuto chooses using the HTMX request headers
+	RenderAuto RenderMode = iota
+	// RenderFull always renders the full page
+	RenderFull
+	// RenderPartial renders only the content, except for history restore requests
+	RenderPartial
+)
+
+// LayoutOption configures a PageLayout.
+type LayoutOption func(*PageLayout)
+
+// PartialTargets limits rendering only the content to HTMX requests that target one of the elements.
+//
+// The targets are element IDs, with or without the leading "#". HTMX requests that target
+// any other element get the full page.
+//
+// Example usage:
+//
+//	hx.LayoutMiddleware(layout, hx.PartialTargets("#main"))
+func PartialTargets(targets ...string) LayoutOption {
+	return func(p *PageLayout) {
+		for _, target := range targets {
+			p.targets = append(p.targets, strings.TrimPrefix(target, "#"))
+		}
+	}
+}
+
+// PageLayout chooses between rendering the full page layout or only the content.
+//
+// The full page is rendered for requests that are not HTMX requests, for boosted requests,
+// and for history restore requests. HTMX requires the full page for history restore
+// requests, so they get the full page even when RenderPartial is used.
+//
+// Use NewPageLayout to create a PageLayout for your own HTTP library.
+type PageLayout struct {
+	layout  Layout
+	targets []string
+}
+
+// NewPageLayout creates a PageLayout for the layout.
+func NewPageLayout(layout Layout, options ...LayoutOption) PageLayout {
+	p := PageLayout{layout: layout}
+	for _, option := range options {
+		option(&p)
+	}
+
+	return p
+}
+
+// Full reports whether the full page should be rendered for the request.
+func (p PageLayout) Full(req Request, mode RenderMode) bool {
+	if req.HistoryRestoreRequest {
+		return true
+	}
+	switch mode {
+	case RenderFull:
+		return true
+	case RenderPartial:
+		return false
+	}
+	if !req.Request || req.Boosted {
+		return true
+	}
+	if len(p.targets) == 0 {
+		return false
+	}
+	for _, target := range p.targets {
+		if target == req.Target {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Fragment returns the content wrapped in the layout when the full page should be rendered,
+// otherwise the content is returned as is.
+func (p PageLayout) Fragment(req Request, mode RenderMode, content Fragment) Fragment {
+	if p.layout == nil || !p.Full(req, mode) {
+		return content
+	}
+
+	return p.layout(content)
+}
+
+type layoutContextKey struct{}
+
+type renderModeContextKey struct{}
+
+// LayoutMiddleware stores the layout in the request context for use by Render.
+//
+// Example usage:
+//
+//	mux := http.NewServeMux()
+//	mux.HandleFunc("/", MyHandler)
+//	http.ListenAndServe(":8080", hx.LayoutMiddleware(layout)(mux))
+func LayoutMiddleware(layout Layout, options ...LayoutOption) func(http.Handler) http.Handler {
+	p := NewPageLayout(layout, options...)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), layoutContextKey{}, p)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RenderModeMiddleware overrides the render mode for the routes it wraps.
+//
+// Example usage:
+//
+//	mux.Handle("/modal", hx.RenderModeMiddleware(hx.RenderPartial)(modalHandler))
+func RenderModeMiddleware(mode RenderMode) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(WithRenderMode(r.Context(), mode)))
+		})
+	}
+}
+
+// WithRenderMode returns a copy of the parent context that overrides the render mode.
+func WithRenderMode(ctx context.Context, mode RenderMode) context.Context {
+	return context.WithValue(ctx, renderModeContextKey{}, mode)
+}
+
+// Render writes the content, wrapped in the layout set by LayoutMiddleware when the full page
+// should be rendered.
+//
+// Without LayoutMiddleware only the content is rendered. The Content-Type header is set to
+// "text/html; charset=utf-8".
+//
+// Example usage:
+//
+//	func MyHandler(w http.ResponseWriter, r *http.Request) {
+//		err := hx.Render(w, r, hx.Template(tmpl, "items", items))
+//		// handle err
+//	}
+func Render(w http.ResponseWriter, r *http.Request, content Fragment) error {
+	ctx := r.Context()
+	fragment := content
+	if p, ok := ctx.Value(layoutContextKey{}).(PageLayout); ok {
+		mode, _ := ctx.Value(renderModeContextKey{}).(RenderMode)
+		req := Request{
+			Boosted:               IsBoosted(r),
+			HistoryRestoreRequest: IsHistoryRestoreRequest(r),
+			Request:               IsHtmx(r),
+			Target:                GetTarget(r),
+		}
+		fragment = p.Fragment(req, mode, content)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	return fragment.Render(ctx, w)
+}
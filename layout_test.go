@@ -0,0 +1,133 @@
+package hx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageLayout_Full(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		options []LayoutOption
+		req     Request
+		mode    RenderMode
+		want    bool
+	}{
+		"Not htmx": {
+			req:  Request{},
+			want: true,
+		},
+		"Htmx": {
+			req:  Request{Request: true},
+			want: false,
+		},
+		"Boosted": {
+			req:  Request{Request: true, Boosted: true},
+			want: true,
+		},
+		"History restore": {
+			req:  Request{Request: true, HistoryRestoreRequest: true},
+			want: true,
+		},
+		"History restore with partial mode": {
+			req:  Request{Request: true, HistoryRestoreRequest: true},
+			mode: RenderPartial,
+			want: true,
+		},
+		"Full mode": {
+			req:  Request{Request: true},
+			mode: RenderFull,
+			want: true,
+		},
+		"Partial mode": {
+			req:  Request{},
+			mode: RenderPartial,
+			want: false,
+		},
+		"Partial target": {
+			options: []LayoutOption{PartialTargets("#main")},
+			req:     Request{Request: true, Target: "main"},
+			want:    false,
+		},
+		"Other target": {
+			options: []LayoutOption{PartialTargets("#main")},
+			req:     Request{Request: true, Target: "sidebar"},
+			want:    true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := NewPageLayout(testLayout, tc.options...)
+			assert.Equal(t, tc.want, p.Full(tc.req, tc.mode))
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		middleware func(http.Handler) http.Handler
+		headers    map[string]string
+		want       string
+	}{
+		"Without layout": {
+			middleware: func(next http.Handler) http.Handler { return next },
+			want:       "content",
+		},
+		"Full page": {
+			middleware: LayoutMiddleware(testLayout),
+			want:       "<main>content</main>",
+		},
+		"Partial": {
+			middleware: LayoutMiddleware(testLayout),
+			headers:    map[string]string{HxRequest: "true"},
+			want:       "content",
+		},
+		"Boosted": {
+			middleware: LayoutMiddleware(testLayout),
+			headers:    map[string]string{HxRequest: "true", HxBoosted: "true"},
+			want:       "<main>content</main>",
+		},
+		"Route override": {
+			middleware: func(next http.Handler) http.Handler {
+				return LayoutMiddleware(testLayout)(RenderModeMiddleware(RenderPartial)(next))
+			},
+			want: "content",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tc.headers {
+				r.Header.Set(k, v)
+			}
+			wr := httptest.NewRecorder()
+			handler := tc.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.NoError(t, Render(w, r, HTML("content")))
+			}))
+
+			handler.ServeHTTP(wr, r)
+
+			assert.Equal(t, tc.want, wr.Body.String())
+			assert.Equal(t, "text/html; charset=utf-8", wr.Header().Get("Content-Type"))
+		})
+	}
+}
+
+func testLayout(content Fragment) Fragment {
+	return FragmentFunc(func(ctx context.Context, w io.Writer) error {
+		_, _ = io.WriteString(w, "<main>")
+		if err := content.Render(ctx, w); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, "</main>")
+		return err
+	})
+}
@@ -23,15 +23,14 @@ import (
 //	  hx.Target("#testdiv"),
 //	))
 //	// Sets HX-Location header to a JSON object: {"path":"/test","target":"#testdiv"}
-func Location(path string, properties ...property) responseOptionFunc {
-	return func(o *HtmxResponse) {
+func Location(path string, properties ...LocationProperty) responseOptionFunc {
+	return func(o *HtmxResponse) error {
 		loc := location{
 			Path: path,
 		}
 
 		if len(properties) == 0 {
-			o.headers[HxLocation] = loc.Path
-			return
+			return o.setHeader(HxLocation, loc.Path)
 		}
 
 		for _, property := range properties {
@@ -40,22 +39,26 @@ func Location(path string, properties ...property) responseOptionFunc {
 
 		value, err := json.Marshal(loc)
 		if err != nil {
-			panic(fmt.Errorf("unable to marshal HX-Location header: %w", err))
+			return fmt.Errorf("%s: %w", HxLocation, err)
 		}
 
-		o.headers[HxLocation] = string(value)
+		return o.setHeader(HxLocation, string(value))
 	}
 }
 
 // Source sets the 'source' property of the HX-Location header.
 //
+// Either a string or a Selector can be used.
+//
 // More details: https://htmx.org/headers/hx-location
-type Source string
-
-func (l Source) apply(o *location) { o.Source = string(l) }
+func Source[T string | Selector](selector T) propertyFunc {
+	return func(o *location) { o.Source = string(selector) }
+}
 
 // EventName sets the 'event' property of the HX-Location header.
 //
+// A TriggerEvent can also be used as a property to set the 'event' property to the complete event object.
+//
 // More details: https://htmx.org/headers/hx-location
 type EventName string
 
@@ -70,18 +73,29 @@ func (l Handler) apply(o *location) { o.Handler = string(l) }
 
 // Target sets the 'target' property of the HX-Location header.
 //
+// Either a string or a Selector can be used.
+//
 // More details: https://htmx.org/headers/hx-location
-type Target string
-
-func (l Target) apply(o *location) { o.Target = string(l) }
+func Target[T string | Selector](selector T) propertyFunc {
+	return func(o *location) { o.Target = string(selector) }
+}
 
 // Swap sets the 'swap' property of the HX-Location header.
 //
-// Either a string or a Reswap constant can be used.
+// Either a string, a Reswap constant, or a SwapSpec can be used.
 //
 // More details: https://htmx.org/headers/hx-location
-func Swap[T string | Reswap](swap T) propertyFunc {
-	return func(o *location) { o.Swap = string(swap) }
+func Swap[T string | Reswap | SwapSpec](swap T) propertyFunc {
+	return func(o *location) {
+		switch s := any(swap).(type) {
+		case SwapSpec:
+			o.Swap = s.String()
+		case Reswap:
+			o.Swap = string(s)
+		case string:
+			o.Swap = s
+		}
+	}
 }
 
 // Values sets the 'values' property of the HX-Location header.
@@ -104,7 +118,9 @@ func (l Headers) apply(o *location) { o.Headers = l }
 
 // Select sets the 'select' property of the HX-Location header.
 //
+// Either a string or a Selector can be used.
+//
 // More details: https://htmx.org/headers/hx-location
-type Select string
-
-func (l Select) apply(o *location) { o.Select = string(l) }
+func Select[T string | Selector](selector T) propertyFunc {
+	return func(o *location) { o.Select = string(selector) }
+}
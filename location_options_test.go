@@ -55,6 +55,22 @@ func TestLocation(t *testing.T) {
 				HxLocation: `{"path":"/foo","swap":"outerHTML focus-scroll:true"}`,
 			},
 		},
+		"Set swap with spec": {
+			location: Location("/foo",
+				Swap(NewSwapSpec(SwapOuterHtml).ShowNone()),
+			),
+			want: map[string]string{
+				HxLocation: `{"path":"/foo","swap":"outerHTML show:none"}`,
+			},
+		},
+		"Set complete event": {
+			location: Location("/foo",
+				EventOn("#bar", "refresh", 1),
+			),
+			want: map[string]string{
+				HxLocation: `{"path":"/foo","event":{"refresh":{"target":"#bar","value":1}}}`,
+			},
+		},
 		"Set them all": {
 			location: Location("/foo",
 				Source("bar"),
@@ -75,7 +91,7 @@ func TestLocation(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			o := &HtmxResponse{headers: make(map[string]string)}
-			tc.location.apply(o)
+			assert.NoError(t, tc.location.apply(o))
 
 			assert.Equal(t, tc.want, o.headers)
 		})
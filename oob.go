@@ -0,0 +1,228 @@
+package hx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// Fragment is a piece of HTML that can be rendered into a response body.
+//
+// The method set matches templ.Component, so templ components can be used as fragments directly.
+// Use Template, WriterTo, or HTML to create fragments from other sources.
+type Fragment interface {
+	Render(ctx context.Context, w io.Writer) error
+}
+
+// FragmentFunc is a function that implements Fragment.
+type FragmentFunc func(ctx context.Context, w io.Writer) error
+
+func (f FragmentFunc) Render(ctx context.Context, w io.Writer) error { return f(ctx, w) }
+
+// Template creates a Fragment that executes the named html/template with the provided data.
+//
+// Example usage:
+//
+//	hx.Template(tmpl, "item", item)
+func Template(t *template.Template, name string, data any) Fragment {
+	return FragmentFunc(func(_ context.Context, w io.Writer) error {
+		return t.ExecuteTemplate(w, name, data)
+	})
+}
+
+// WriterTo creates a Fragment from an io.WriterTo, such as a *bytes.Buffer.
+func WriterTo(wt io.WriterTo) Fragment {
+	return FragmentFunc(func(_ context.Context, w io.Writer) error {
+		_, err := wt.WriteTo(w)
+		return err
+	})
+}
+
+// HTML creates a Fragment from trusted HTML.
+func HTML(html template.HTML) Fragment {
+	return FragmentFunc(func(_ context.Context, w io.Writer) error {
+		_, err := io.WriteString(w, string(html))
+		return err
+	})
+}
+
+// OOBFragment is a fragment that is swapped in out-of-band using the hx-swap-oob attribute.
+//
+// The fragment is wrapped in an element carrying the hx-swap-oob attribute. For most swap
+// styles htmx swaps the children of the wrapper into the target. For outerHTML the wrapper
+// itself replaces the target, so use Tag and ID to make the wrapper match the target element.
+//
+// Use OOBSwap to create an OOBFragment.
+type OOBFragment struct {
+	swap     Reswap
+	selector string
+	tag      string
+	id       string
+	fragment Fragment
+}
+
+// OOBSwap creates an out-of-band fragment that is swapped into the selected element.
+//
+// The swap style may be any of the Swap constants; swap modifiers are not supported
+// by the hx-swap-oob attribute. When the selector is empty htmx will use the ID of the
+// wrapping element as the target.
+//
+// More details: https://htmx.org/attributes/hx-swap-oob
+//
+// Example usage:
+//
+//	hx.OOBSwap(hx.SwapBeforeEnd, "#list", hx.Template(tmpl, "item", item))
+//	// Renders <div hx-swap-oob="beforeend:#list">...</div>
+func OOBSwap[T string | Selector](swap Reswap, selector T, fragment Fragment) OOBFragment {
+	return OOBFragment{
+		swap:     swap,
+		selector: string(selector),
+		tag:      "div",
+		fragment: fragment,
+	}
+}
+
+// Tag sets the tag of the wrapping element; the default is "div".
+//
+// Elements such as table rows need a matching wrapper, e.g. "tbody", to be parsed correctly by the browser.
+// The tag must be a plain element name made of letters, digits, and dashes, starting with a letter;
+// Render returns an error for any other tag.
+func (f OOBFragment) Tag(tag string) OOBFragment {
+	f.tag = tag
+	return f
+}
+
+// ID sets the id attribute of the wrapping element.
+func (f OOBFragment) ID(id string) OOBFragment {
+	f.id = id
+	return f
+}
+
+// Value returns the value of the hx-swap-oob attribute for this fragment.
+func (f OOBFragment) Value() (string, error) {
+	style := string(f.swap)
+	if strings.ContainsAny(style, " :") {
+		return "", fmt.Errorf("hx-swap-oob does not support swap modifiers: %q", style)
+	}
+	if style == "" {
+		style = "true"
+	}
+	if f.selector == "" {
+		return style, nil
+	}
+	if style == "true" {
+		style = string(SwapOuterHtml)
+	}
+
+	return style + ":" + f.selector, nil
+}
+
+// Render writes the fragment wrapped in an element with the hx-swap-oob attribute.
+func (f OOBFragment) Render(ctx context.Context, w io.Writer) error {
+	if f.tag == "" {
+		return errors.New("hx-swap-oob requires a wrapping element")
+	}
+	if !isTagName(f.tag) {
+		return fmt.Errorf("invalid wrapping element %q", f.tag)
+	}
+	value, err := f.Value()
+	if err != nil {
+		return err
+	}
+
+	open := "<" + f.tag
+	if f.id != "" {
+		open += ` id="` + template.HTMLEscapeString(f.id) + `"`
+	}
+	open += ` hx-swap-oob="` + template.HTMLEscapeString(value) + `">`
+	if _, err = io.WriteString(w, open); err != nil {
+		return err
+	}
+	if f.fragment != nil {
+		if err = f.fragment.Render(ctx, w); err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(w, "</"+f.tag+">")
+
+	return err
+}
+
+// isTagName reports whether the tag is a plain element name such as "div" or "my-element"
+func isTagName(tag string) bool {
+	for i, c := range tag {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case i > 0 && (c >= '0' && c <= '9' || c == '-'):
+		default:
+			return false
+		}
+	}
+	return tag != ""
+}
+
+// OOBResponse is a response body made of a primary fragment and any number of out-of-band fragments.
+//
+// Use OOB to create an OOBResponse. It implements both Fragment and io.WriterTo.
+type OOBResponse struct {
+	primary Fragment
+	oob     []OOBFragment
+}
+
+// OOB composes a response body from a primary fragment and out-of-band fragments.
+//
+// The primary fragment is swapped into the target of the request as normal, while the
+// out-of-band fragments are swapped into the elements they select. The primary fragment
+// may be nil when the response only contains out-of-band content.
+//
+// Example usage:
+//
+//	body := hx.OOB(hx.Template(tmpl, "form", form),
+//		hx.OOBSwap(hx.SwapBeforeEnd, "#list", hx.Template(tmpl, "item", item)),
+//		hx.OOBSwap(hx.SwapInnerHtml, "#count", hx.HTML("42")),
+//	)
+//	_, err := body.WriteTo(w)
+func OOB(primary Fragment, oob ...OOBFragment) OOBResponse {
+	return OOBResponse{
+		primary: primary,
+		oob:     oob,
+	}
+}
+
+// Render writes the primary fragment followed by each of the out-of-band fragments.
+func (r OOBResponse) Render(ctx context.Context, w io.Writer) error {
+	if r.primary != nil {
+		if err := r.primary.Render(ctx, w); err != nil {
+			return err
+		}
+	}
+	for _, f := range r.oob {
+		if err := f.Render(ctx, w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteTo writes the response using a background context.
+func (r OOBResponse) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	err := r.Render(context.Background(), cw)
+
+	return cw.n, err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
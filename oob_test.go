@@ -0,0 +1,133 @@
+package hx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"html/template"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOOBFragment_Value(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		fragment OOBFragment
+		want     string
+		wantErr  bool
+	}{
+		"Use element id": {
+			fragment: OOBSwap("", "", nil),
+			want:     "true",
+		},
+		"Swap style": {
+			fragment: OOBSwap(SwapInnerHtml, "", nil),
+			want:     "innerHTML",
+		},
+		"Swap style with selector": {
+			fragment: OOBSwap(SwapBeforeEnd, "#list", nil),
+			want:     "beforeend:#list",
+		},
+		"Selector only": {
+			fragment: OOBSwap("", "#list", nil),
+			want:     "outerHTML:#list",
+		},
+		"Modifiers": {
+			fragment: OOBSwap(SwapInnerHtml.Transition(), "#list", nil),
+			wantErr:  true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.fragment.Value()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOOB(t *testing.T) {
+	t.Parallel()
+
+	tmpl := template.Must(template.New("item").Parse(`<li>{{ . }}</li>`))
+
+	tests := map[string]struct {
+		response OOBResponse
+		want     string
+		wantErr  bool
+	}{
+		"Primary only": {
+			response: OOB(HTML("<p>primary</p>")),
+			want:     "<p>primary</p>",
+		},
+		"Primary and out-of-band": {
+			response: OOB(HTML("<p>primary</p>"),
+				OOBSwap(SwapBeforeEnd, "#list", Template(tmpl, "item", "<b>escaped</b>")),
+				OOBSwap(SwapInnerHtml, "#count", WriterTo(bytes.NewBufferString("42"))),
+			),
+			want: `<p>primary</p>` +
+				`<div hx-swap-oob="beforeend:#list"><li>&lt;b&gt;escaped&lt;/b&gt;</li></div>` +
+				`<div hx-swap-oob="innerHTML:#count">42</div>`,
+		},
+		"Out-of-band only": {
+			response: OOB(nil,
+				OOBSwap(SwapOuterHtml, "", HTML("<td>1</td>")).Tag("tr").ID("row-1"),
+			),
+			want: `<tr id="row-1" hx-swap-oob="outerHTML"><td>1</td></tr>`,
+		},
+		"Escapes the selector": {
+			response: OOB(nil,
+				OOBSwap(SwapInnerHtml, `[data-name="x"]`, HTML("x")),
+			),
+			want: `<div hx-swap-oob="innerHTML:[data-name=&#34;x&#34;]">x</div>`,
+		},
+		"Fragment error": {
+			response: OOB(nil,
+				OOBSwap(SwapInnerHtml, "#x", FragmentFunc(func(context.Context, io.Writer) error {
+					return errors.New("bad fragment")
+				})),
+			),
+			wantErr: true,
+		},
+		"Custom element tag": {
+			response: OOB(nil, OOBSwap(SwapInnerHtml, "#x", HTML("x")).Tag("my-list2")),
+			want:     `<my-list2 hx-swap-oob="innerHTML:#x">x</my-list2>`,
+		},
+		"Tag with attributes error": {
+			response: OOB(nil, OOBSwap(SwapInnerHtml, "#x", HTML("x")).Tag("div onmouseover=alert(1)")),
+			wantErr:  true,
+		},
+		"Tag with markup error": {
+			response: OOB(nil, OOBSwap(SwapInnerHtml, "#x", HTML("x")).Tag("div><script>")),
+			wantErr:  true,
+		},
+		"Tag starting with a digit error": {
+			response: OOB(nil, OOBSwap(SwapInnerHtml, "#x", HTML("x")).Tag("1div")),
+			wantErr:  true,
+		},
+		"Modifiers error": {
+			response: OOB(nil, OOBSwap(SwapInnerHtml.IgnoreTitle(), "#x", HTML("x"))),
+			wantErr:  true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n, err := tt.response.WriteTo(&buf)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+			assert.Equal(t, int64(buf.Len()), n)
+		})
+	}
+}
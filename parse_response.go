@@ -0,0 +1,190 @@
+package hx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ParsedResponse contains the HTMX response headers decoded back into typed values.
+//
+// Use ParseResponse to create one from the headers of an HTTP response.
+type ParsedResponse struct {
+	Location           *ParsedLocation
+	PushUrl            string
+	Redirect           string
+	Refresh            bool
+	ReplaceUrl         string
+	Reswap             *SwapSpec
+	Retarget           string
+	Reselect           string
+	Trigger            []ParsedEvent
+	TriggerAfterSettle []ParsedEvent
+	TriggerAfterSwap   []ParsedEvent
+}
+
+// ParsedLocation contains the decoded value of the HX-Location header.
+//
+// A HX-Location header that only contains a path will only have the Path field set.
+// Event is a string for an event name, or a map for a complete event object.
+type ParsedLocation struct {
+	Path    string            `json:"path"`
+	Source  string            `json:"source,omitempty"`
+	Event   any               `json:"event,omitempty"`
+	Handler string            `json:"handler,omitempty"`
+	Target  string            `json:"target,omitempty"`
+	Swap    string            `json:"swap,omitempty"`
+	Values  any               `json:"values,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Select  string            `json:"select,omitempty"`
+}
+
+// ParsedEvent is a single event decoded from one of the trigger headers.
+//
+// Data contains the raw JSON value of the event and will be nil for events
+// without any data. Use json.Unmarshal to decode it into your own type.
+type ParsedEvent struct {
+	Name string
+	Data json.RawMessage
+}
+
+// ParseResponse decodes the HTMX headers found in the provided http.Header.
+//
+// This is the reverse of BuildResponse and is useful in tests, or when proxying
+// HTMX responses between Go services.
+//
+// Example usage:
+//
+//	resp, err := http.Get("http://localhost:8080/foo")
+//	// handle err
+//	parsed, err := hx.ParseResponse(resp.Header)
+//	// handle err
+//	fmt.Println(parsed.Reswap.Style())
+func ParseResponse(h http.Header) (*ParsedResponse, error) {
+	p := &ParsedResponse{
+		PushUrl:    h.Get(HxPushUrl),
+		Redirect:   h.Get(HxRedirect),
+		Refresh:    h.Get(HxRefresh) == "true",
+		ReplaceUrl: h.Get(HxReplaceUrl),
+		Retarget:   h.Get(HxRetarget),
+		Reselect:   h.Get(HxReselect),
+	}
+
+	var err error
+	if value := h.Get(HxLocation); value != "" {
+		if p.Location, err = ParseLocation(value); err != nil {
+			return nil, err
+		}
+	}
+	if value := h.Get(HxReswap); value != "" {
+		if p.Reswap, err = ParseReswap(value); err != nil {
+			return nil, err
+		}
+	}
+	if p.Trigger, err = parseTriggerHeader(h, HxTrigger); err != nil {
+		return nil, err
+	}
+	if p.TriggerAfterSettle, err = parseTriggerHeader(h, HxTriggerAfterSettle); err != nil {
+		return nil, err
+	}
+	if p.TriggerAfterSwap, err = parseTriggerHeader(h, HxTriggerAfterSwap); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// ParseLocation decodes the value of a HX-Location header.
+//
+// Both the simple path form and the JSON object form are supported.
+func ParseLocation(value string) (*ParsedLocation, error) {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "{") {
+		return &ParsedLocation{Path: value}, nil
+	}
+
+	var loc location
+	if err := json.Unmarshal([]byte(value), &loc); err != nil {
+		return nil, fmt.Errorf("unable to parse HX-Location header: %w", err)
+	}
+	parsed := ParsedLocation(loc)
+
+	return &parsed, nil
+}
+
+// ParseReswap decodes the value of a HX-Reswap header, or the swap property of
+// the HX-Location header, into a SwapSpec.
+//
+// Example usage:
+//
+//	spec, err := hx.ParseReswap("innerHTML swap:1s settle:2s")
+//	// spec.Style() == hx.SwapInnerHtml
+//	// spec.SettleDelay() == 2*time.Second, true
+func ParseReswap(value string) (*SwapSpec, error) {
+	r, err := parseReswap(value, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse HX-Reswap header: %w", err)
+	}
+
+	return r, nil
+}
+
+func parseTriggerHeader(h http.Header, header string) ([]ParsedEvent, error) {
+	value := strings.TrimSpace(h.Get(header))
+	if value == "" {
+		return nil, nil
+	}
+
+	events, err := parseTriggerValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s header: %w", header, err)
+	}
+
+	return events, nil
+}
+
+// parseTriggerValue decodes either the JSON object form or the comma separated
+// form of a trigger header, keeping the order in which the events were sent.
+func parseTriggerValue(value string) ([]ParsedEvent, error) {
+	if !strings.HasPrefix(value, "{") {
+		var events []ParsedEvent
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				events = append(events, ParsedEvent{Name: name})
+			}
+		}
+		return events, nil
+	}
+
+	dec := json.NewDecoder(strings.NewReader(value))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	var events []ParsedEvent
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		name, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected event name %v", token)
+		}
+		var data json.RawMessage
+		if err := dec.Decode(&data); err != nil {
+			return nil, err
+		}
+		if bytes.Equal(data, []byte("null")) {
+			data = nil
+		}
+		events = append(events, ParsedEvent{Name: name, Data: data})
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -0,0 +1,213 @@
+package hx
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseResponse(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		options []ResponseOption
+		want    *ParsedResponse
+		wantErr bool
+	}{
+		"No headers": {
+			options: []ResponseOption{},
+			want:    &ParsedResponse{},
+		},
+		"Simple headers": {
+			options: []ResponseOption{
+				PushUrl("/push"),
+				Redirect("/redirect"),
+				Refresh(),
+				ReplaceUrl("/replace"),
+				Retarget("#target"),
+				Reselect("#select"),
+			},
+			want: &ParsedResponse{
+				PushUrl:    "/push",
+				Redirect:   "/redirect",
+				Refresh:    true,
+				ReplaceUrl: "/replace",
+				Retarget:   "#target",
+				Reselect:   "#select",
+			},
+		},
+		"Location path": {
+			options: []ResponseOption{
+				Location("/foo"),
+			},
+			want: &ParsedResponse{
+				Location: &ParsedLocation{Path: "/foo"},
+			},
+		},
+		"Location properties": {
+			options: []ResponseOption{
+				Location("/foo",
+					Target("#bar"),
+					Swap(SwapOuterHtml.Transition()),
+					Values(map[string]any{"k": "v"}),
+					Headers{"X-Foo": "bar"},
+				),
+			},
+			want: &ParsedResponse{
+				Location: &ParsedLocation{
+					Path:    "/foo",
+					Target:  "#bar",
+					Swap:    "outerHTML transition:true",
+					Values:  map[string]any{"k": "v"},
+					Headers: map[string]string{"X-Foo": "bar"},
+				},
+			},
+		},
+		"Reswap with modifiers": {
+			options: []ResponseOption{
+				SwapInnerHtml.Swap(time.Second).Settle(1500 * time.Millisecond).Scroll("#foo:top").FocusScroll(true),
+			},
+			want: &ParsedResponse{
+				Reswap: swapSpecPtr(NewSwapSpec(SwapInnerHtml).Swap(time.Second).Settle(1500*time.Millisecond).ScrollElement("#foo", ScrollTop).FocusScroll(true)),
+			},
+		},
+		"Triggers": {
+			options: []ResponseOption{
+				Trigger(Event("myEvent")),
+				TriggerAfterSettle(Event("mySettleEvent", "foo")),
+				TriggerAfterSwap(Event("mySwapEvent", map[string]int{"k": 1})),
+			},
+			want: &ParsedResponse{
+				Trigger:            []ParsedEvent{{Name: "myEvent"}},
+				TriggerAfterSettle: []ParsedEvent{{Name: "mySettleEvent", Data: json.RawMessage(`"foo"`)}},
+				TriggerAfterSwap:   []ParsedEvent{{Name: "mySwapEvent", Data: json.RawMessage(`{"k":1}`)}},
+			},
+		},
+		"Bad location": {
+			options: []ResponseOption{
+				responseOptionFunc(func(o *HtmxResponse) error { return o.setHeader(HxLocation, `{"path":`) }),
+			},
+			wantErr: true,
+		},
+		"Bad reswap modifier": {
+			options: []ResponseOption{
+				Reswap("innerHTML foo:bar"),
+			},
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			wr := httptest.NewRecorder()
+			assert.NoError(t, Response(wr, tc.options...))
+
+			got, err := ParseResponse(wr.Header())
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseReswap(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value   string
+		want    SwapSpec
+		wantErr bool
+	}{
+		"Style only": {
+			value: "outerHTML",
+			want:  NewSwapSpec(SwapOuterHtml),
+		},
+		"Modifiers only": {
+			value: "swap:100ms",
+			want:  NewSwapSpec("").Swap(100 * time.Millisecond),
+		},
+		"Plain milliseconds": {
+			value: "innerHTML settle:250",
+			want:  NewSwapSpec(SwapInnerHtml).Settle(250 * time.Millisecond),
+		},
+		"All modifiers": {
+			value: "beforeend transition:true swap:1s settle:2s ignoreTitle:true scroll:bottom show:#foo:top focus-scroll:false",
+			want: NewSwapSpec(SwapBeforeEnd).
+				Transition().
+				Swap(time.Second).
+				Settle(2*time.Second).
+				IgnoreTitle().
+				Scroll(ScrollBottom).
+				ShowElement("#foo", ScrollTop).
+				FocusScroll(false),
+		},
+		"Bad duration": {
+			value:   "innerHTML swap:soon",
+			wantErr: true,
+		},
+		"Unknown modifier": {
+			value:   "innerHTML foo:bar",
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseReswap(tc.value)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, *got)
+		})
+	}
+}
+
+func swapSpecPtr(s SwapSpec) *SwapSpec { return &s }
+
+func TestParseTriggerValue(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value   string
+		want    []ParsedEvent
+		wantErr bool
+	}{
+		"Single name": {
+			value: "myEvent",
+			want:  []ParsedEvent{{Name: "myEvent"}},
+		},
+		"Comma separated": {
+			value: "myEvent, myOtherEvent",
+			want:  []ParsedEvent{{Name: "myEvent"}, {Name: "myOtherEvent"}},
+		},
+		"JSON keeps order": {
+			value: `{"b":1,"a":null,"c":{"k":"v"}}`,
+			want: []ParsedEvent{
+				{Name: "b", Data: json.RawMessage(`1`)},
+				{Name: "a"},
+				{Name: "c", Data: json.RawMessage(`{"k":"v"}`)},
+			},
+		},
+		"Bad JSON": {
+			value:   `{"a":}`,
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseTriggerValue(tc.value)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package hx
 
 import (
 	"net/http"
+	"net/url"
 )
 
 // Request Headers
@@ -60,7 +61,7 @@ const (
 //
 // Returns true if the request is a boosted request
 func IsBoosted(r *http.Request) bool {
-	return r.Header.Get(HxBoosted) != ""
+	return readHeaders(r).IsBoosted()
 }
 
 // GetCurrentUrl extracts the HX-Current-URL header from an HTTP request.
@@ -68,15 +69,15 @@ func IsBoosted(r *http.Request) bool {
 // It returns the current URL of the browser if the header exists.
 // If the header is not present, it returns an empty string.
 func GetCurrentUrl(r *http.Request) string {
-	return r.Header.Get(HxCurrentUrl)
+	return readHeaders(r).GetCurrentUrl()
 }
 
 // IsHistoryRestoreRequest determines if an HTTP request is a history restore request.
 //
 // It checks the presence of the HX-History-Restore-Request header in the request.
-// Returns true if the header is present, otherwise returns false.
+// Returns true if the header is present and not "false", otherwise returns false.
 func IsHistoryRestoreRequest(r *http.Request) bool {
-	return r.Header.Get(HxHistoryRestoreRequest) != ""
+	return readHeaders(r).IsHistoryRestoreRequest()
 }
 
 // GetPrompt extracts the HX-Prompt header from an HTTP request.
@@ -84,15 +85,15 @@ func IsHistoryRestoreRequest(r *http.Request) bool {
 // It returns the user response to an hx-prompt if the header exists.
 // If the header is not present, it returns an empty string.
 func GetPrompt(r *http.Request) string {
-	return r.Header.Get(HxPrompt)
+	return readHeaders(r).GetPrompt()
 }
 
 // IsRequest determines if an HTTP request is an HTMX request.
 //
 // It checks the presence of the HX-Request header in the request.
-// Returns true if the header is present, otherwise returns false.
+// Returns true if the header is present and not "false", otherwise returns false.
 func IsRequest(r *http.Request) bool {
-	return r.Header.Get(HxRequest) != ""
+	return readHeaders(r).IsRequest()
 }
 
 // IsHtmx determines if an HTTP request is an HTMX request.
@@ -107,7 +108,7 @@ func IsHtmx(r *http.Request) bool {
 // It returns the ID of the target element if the header exists.
 // If the header is not present, it returns an empty string.
 func GetTarget(r *http.Request) string {
-	return r.Header.Get(HxTarget)
+	return readHeaders(r).GetTarget()
 }
 
 // GetTriggerName extracts the HX-Trigger-Name header from an HTTP request.
@@ -115,7 +116,7 @@ func GetTarget(r *http.Request) string {
 // It returns the name of the triggered element if the header exists.
 // If the header is not present, it returns an empty string.
 func GetTriggerName(r *http.Request) string {
-	return r.Header.Get(HxTriggerName)
+	return readHeaders(r).GetTriggerName()
 }
 
 // GetTrigger extracts the HX-Trigger header from an HTTP request.
@@ -123,5 +124,58 @@ func GetTriggerName(r *http.Request) string {
 // It returns the ID of the trigger element if the header exists.
 // If the header is not present, it returns an empty string.
 func GetTrigger(r *http.Request) string {
-	return r.Header.Get(HxTrigger)
+	return readHeaders(r).GetTrigger()
+}
+
+// Request contains all the HTMX request headers parsed into typed values.
+//
+// Use ParseRequest to create one from an HTTP request.
+type Request struct {
+	// Boosted is true if the HX-Boosted header is present and not "false"
+	Boosted bool
+	// CurrentUrl is the parsed HX-Current-URL header; nil if the header is missing or invalid
+	CurrentUrl *url.URL
+	// HistoryRestoreRequest is true if the HX-History-Restore-Request header is present and not "false"
+	HistoryRestoreRequest bool
+	// Prompt is the user response to an hx-prompt
+	Prompt string
+	// Request is true if the HX-Request header is present and not "false"
+	Request bool
+	// Target is the ID of the target element
+	Target string
+	// Trigger is the ID of the triggered element
+	Trigger string
+	// TriggerName is the name of the triggered element
+	TriggerName string
+	// Version is the htmx version sent in the VersionHeader
+	Version Version
+}
+
+// ParseRequest parses all the HTMX request headers from an HTTP request.
+//
+// When VaryMiddleware is in use, the HX-Request, HX-Boosted, HX-History-Restore-Request, and
+// HX-Target headers are added to the Vary header of the response. The other headers are not,
+// so call their getters, such as GetTrigger, when the response depends on them.
+//
+// Example usage:
+//
+//	req := hx.ParseRequest(r)
+//	if req.Request && !req.Boosted {
+//		// render a partial for req.Target
+//	}
+func ParseRequest(r *http.Request) Request {
+	return readHeaders(r).Parse()
+}
+
+// ParseHeader parses all the HTMX request headers from an http.Header, or any other HeaderGetter.
+//
+// It can be used to fill a Request for your own HTTP library. Nothing is added to the Vary
+// header; use ReadRequest with an Adapter that implements VaryAdapter for that.
+func ParseHeader(h HeaderGetter) Request {
+	return ReadHeaders(h).Parse()
+}
+
+// readHeaders reads the request headers, adding them to the Vary header when VaryMiddleware is in use
+func readHeaders(r *http.Request) HeaderReader {
+	return ReadRequest(httpAdapter{r: r})
 }
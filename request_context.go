@@ -0,0 +1,49 @@
+package hx
+
+import (
+	"context"
+	"net/http"
+)
+
+type requestContextKey struct{}
+
+// NewContext returns a copy of the parent context that carries the parsed HTMX request.
+func NewContext(ctx context.Context, req Request) context.Context {
+	return context.WithValue(ctx, requestContextKey{}, req)
+}
+
+// FromContext returns the parsed HTMX request stored in the context.
+//
+// The boolean is false if RequestMiddleware, or NewContext, was not used to add
+// the request to the context.
+//
+// Example usage:
+//
+//	func MyHandler(w http.ResponseWriter, r *http.Request) {
+//		req, _ := hx.FromContext(r.Context())
+//		if req.Request {
+//			// do something
+//		}
+//	}
+func FromContext(ctx context.Context) (Request, bool) {
+	req, ok := ctx.Value(requestContextKey{}).(Request)
+	return req, ok
+}
+
+// RequestMiddleware parses the HTMX request headers and stores the result in the request context.
+//
+// Use FromContext to fetch the parsed request in your handlers. When it is wrapped by
+// VaryMiddleware, the headers added by ParseRequest are added to the Vary header of every
+// response, whether the handler uses them or not.
+//
+// Example usage:
+//
+//	mux := http.NewServeMux()
+//	mux.HandleFunc("/", MyHandler)
+//	http.ListenAndServe(":8080", hx.RequestMiddleware(mux))
+func RequestMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := NewContext(r.Context(), ParseRequest(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,56 @@
+package hx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		ctx    context.Context
+		want   Request
+		wantOk bool
+	}{
+		"With request": {
+			ctx:    NewContext(context.Background(), Request{Request: true, Target: "foo"}),
+			want:   Request{Request: true, Target: "foo"},
+			wantOk: true,
+		},
+		"Without request": {
+			ctx:    context.Background(),
+			want:   Request{},
+			wantOk: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := FromContext(tt.ctx)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestRequestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var got Request
+	var gotOk bool
+	handler := RequestMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, gotOk = FromContext(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(HxRequest, "true")
+	r.Header.Set(HxTarget, "foo")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.True(t, gotOk)
+	assert.Equal(t, Request{Request: true, Target: "foo"}, got)
+}
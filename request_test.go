@@ -2,6 +2,7 @@ package hx
 
 import (
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -330,3 +331,53 @@ func TestIsRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		header http.Header
+		want   Request
+	}{
+		"Every header": {
+			header: http.Header{
+				"Hx-Boosted":                 []string{"true"},
+				"Hx-Current-Url":             []string{"http://localhost/foo?bar=baz"},
+				"Hx-History-Restore-Request": []string{"true"},
+				"Hx-Prompt":                  []string{"yes"},
+				"Hx-Request":                 []string{"true"},
+				"Hx-Target":                  []string{"list"},
+				"Hx-Trigger":                 []string{"btn"},
+				"Hx-Trigger-Name":            []string{"button"},
+			},
+			want: Request{
+				Boosted:               true,
+				CurrentUrl:            &url.URL{Scheme: "http", Host: "localhost", Path: "/foo", RawQuery: "bar=baz"},
+				HistoryRestoreRequest: true,
+				Prompt:                "yes",
+				Request:               true,
+				Target:                "list",
+				Trigger:               "btn",
+				TriggerName:           "button",
+			},
+		},
+		"Invalid current url": {
+			header: http.Header{
+				"Hx-Request":     []string{"true"},
+				"Hx-Current-Url": []string{"http://[::1"},
+			},
+			want: Request{
+				Request: true,
+			},
+		},
+		"Blank": {
+			header: http.Header{},
+			want:   Request{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equalf(t, tt.want, ParseRequest(&http.Request{Header: tt.header}), "Headers: %v", tt.header)
+		})
+	}
+}
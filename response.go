@@ -1,6 +1,8 @@
 package hx
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 )
 
@@ -74,7 +76,7 @@ const (
 	// See https://htmx.org/reference/#response_headers for more details.
 	//
 	// Use the TriggerAfterSwap() option to set this header in the response.
-	HxTriggerAfterSwap = "Hx-Trigger-After-Reswap"
+	HxTriggerAfterSwap = "Hx-Trigger-After-Swap"
 )
 
 // Response modifies the http.ResponseWriter to add HTMX headers and status codes.
@@ -87,53 +89,79 @@ const (
 //   - Refresh(bool): If set to "true", triggers a full refresh of the client-side page.
 //   - ReplaceUrl(string): Replaces the current URL in the location bar.
 //   - Reswap(string) | {Swap constants}: Specifies how the response will be swapped.
-//   - Retarget(string): A CSS selector to update the target of the content update to a different page element.
-//   - Reselect(string): A CSS selector to select a part of the response to be swapped in, overriding existing hx-select on the triggering element.
+//   - Retarget(string | Selector): A CSS selector to update the target of the content update to a different page element.
+//   - Reselect(string | Selector): A CSS selector to select a part of the response to be swapped in, overriding existing hx-select on the triggering element.
 //   - Trigger(...events): Triggers client-side events.
 //   - TriggerAfterSettle(...events): Triggers client-side events after the settle step.
 //   - TriggerAfterSwap(...events): Triggers client-side events after the swap step.
+//
+// Trigger events are merged with any events that have already been set on the http.ResponseWriter,
+// for example by a middleware that also called Response.
 func Response(w http.ResponseWriter, options ...ResponseOption) error {
-	o, err := BuildResponse(options...)
-	if err != nil {
-		return err
-	}
-
-	if len(o.headers) > 0 {
-		for k, v := range o.headers {
-			w.Header().Set(k, v)
-		}
-	}
-
-	// Support setting the stop polling status code.
-	if o.status != 0 {
-		w.WriteHeader(o.status)
-	}
-
-	return nil
+	_, err := ResponseFor(httpAdapter{w: w}, options...)
+	return err
 }
 
 // BuildResponse creates a new HtmxResponse from the provided options.
 //
-// It can be used to create a response helper for your own HTTP library.
+// It can be used to create a response helper for your own HTTP library, though
+// implementing an Adapter and using ResponseFor is often simpler.
+//
+// The errors returned by the options are joined together using errors.Join. Options that
+// are not supported by the htmx Version of the response are also reported as errors.
 //
 // Several libraries have already been implemented:
 //   - Echo: import github.com/stackus/hxgo/hxecho
+//   - fasthttp: import github.com/stackus/hxgo/hxfasthttp
 //   - Fiber: import github.com/stackus/hxgo/hxfiber
 //   - Gin: import github.com/stackus/hxgo/hxgin
-func BuildResponse(options ...ResponseOption) (response *HtmxResponse, err error) {
-	// Recover from any panics that might happen during the processing of the options.
-	defer func() {
-		if r := recover(); r != nil {
-			err = r.(error)
-		}
-	}()
-
+func BuildResponse(options ...ResponseOption) (*HtmxResponse, error) {
 	o := &HtmxResponse{
 		headers: make(map[string]string),
+		version: DefaultVersion,
 	}
+
+	var errs []error
 	for _, option := range options {
-		option.apply(o)
+		if err := option.apply(o); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if o.strict {
+		if err := o.validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := o.validateVersion(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
 	}
 
 	return o, nil
 }
+
+// validate checks the headers that are validated in strict mode
+func (r *HtmxResponse) validate() error {
+	if value, ok := r.headers[HxReswap]; ok {
+		if err := Reswap(value).Validate(); err != nil {
+			return fmt.Errorf("%s: %w", HxReswap, err)
+		}
+	}
+	if value, ok := r.headers[HxLocation]; ok {
+		loc, err := ParseLocation(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", HxLocation, err)
+		}
+		if loc.Swap != "" {
+			if err = Reswap(loc.Swap).Validate(); err != nil {
+				return fmt.Errorf("%s: %w", HxLocation, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -8,7 +8,7 @@ package hx
 //	// Sets the HTTP status code to 410.
 type Status int
 
-func (s Status) apply(o *HtmxResponse) { o.status = int(s) }
+func (s Status) apply(o *HtmxResponse) error { return o.setStatus(int(s)) }
 
 // HTMX status codes.
 const (
@@ -30,7 +30,7 @@ const (
 //	// Sets the HX-Push-Url header to "/new-url-location".
 type PushUrl string
 
-func (p PushUrl) apply(o *HtmxResponse) { o.headers[HxPushUrl] = string(p) }
+func (p PushUrl) apply(o *HtmxResponse) error { return o.setHeader(HxPushUrl, string(p)) }
 
 // Redirect sets the HX-Redirect header.
 //
@@ -42,7 +42,7 @@ func (p PushUrl) apply(o *HtmxResponse) { o.headers[HxPushUrl] = string(p) }
 //	// Sets the HX-Redirect header to "/new-url-location".
 type Redirect string
 
-func (r Redirect) apply(o *HtmxResponse) { o.headers[HxRedirect] = string(r) }
+func (r Redirect) apply(o *HtmxResponse) error { return o.setHeader(HxRedirect, string(r)) }
 
 // Refresh sets the HX-Refresh header.
 //
@@ -54,8 +54,25 @@ func (r Redirect) apply(o *HtmxResponse) { o.headers[HxRedirect] = string(r) }
 //	hx.Response(w, hx.Refresh())
 //	// Sets the HX-Refresh header to "true".
 func Refresh() responseOptionFunc {
-	return func(o *HtmxResponse) {
-		o.headers[HxRefresh] = "true"
+	return func(o *HtmxResponse) error {
+		return o.setHeader(HxRefresh, "true")
+	}
+}
+
+// Strict enables validation of the response when it is built.
+//
+// In strict mode the HX-Reswap header and the swap property of the HX-Location header
+// are validated using Reswap.Validate. Validation errors are returned by BuildResponse
+// and Response instead of failing later in the browser.
+//
+// Example usage:
+//
+//	err := hx.Response(w, hx.Strict(), hx.Reswap("innerHtml"))
+//	// Returns an error because the swap style is "innerHTML"
+func Strict() responseOptionFunc {
+	return func(o *HtmxResponse) error {
+		o.strict = true
+		return nil
 	}
 }
 
@@ -69,28 +86,37 @@ func Refresh() responseOptionFunc {
 //	// Sets the HX-Replace-Url header to "/new-url-location".
 type ReplaceUrl string
 
-func (r ReplaceUrl) apply(o *HtmxResponse) { o.headers[HxReplaceUrl] = string(r) }
+func (r ReplaceUrl) apply(o *HtmxResponse) error { return o.setHeader(HxReplaceUrl, string(r)) }
 
 // Retarget sets the HX-Retarget header.
 //
 // This option specifies a new CSS selector to redirect the content update to a different element on the page.
+// Either a string or a Selector can be used.
 //
 // Example usage:
 //
 //	hx.Response(w, hx.Retarget("#new-target"))
 //	// Sets the HX-Retarget header to "#new-target".
-type Retarget string
-
-func (t Retarget) apply(o *HtmxResponse) { o.headers[HxRetarget] = string(t) }
+//
+//	hx.Response(w, hx.Retarget(hx.Closest("tr")))
+//	// Sets the HX-Retarget header to "closest tr".
+func Retarget[T string | Selector](selector T) responseOptionFunc {
+	return func(o *HtmxResponse) error {
+		return o.setHeader(HxRetarget, string(selector))
+	}
+}
 
 // Reselect sets the HX-Reselect header.
 //
 // This option designates a CSS selector to determine which part of the response should be used for swapping in, effectively overriding any existing hx-select on the triggering element.
+// Either a string or a Selector can be used.
 //
 // Example usage:
 //
 //	hx.Response(w, hx.Reselect("#new-target"))
 //	// Sets the HX-Reselect header to "#new-target".
-type Reselect string
-
-func (s Reselect) apply(o *HtmxResponse) { o.headers[HxReselect] = string(s) }
+func Reselect[T string | Selector](selector T) responseOptionFunc {
+	return func(o *HtmxResponse) error {
+		return o.setHeader(HxReselect, string(selector))
+	}
+}
@@ -23,7 +23,7 @@ func TestPushUrl(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			o := &HtmxResponse{headers: make(map[string]string)}
-			tc.options.apply(o)
+			assert.NoError(t, tc.options.apply(o))
 
 			assert.Equal(t, tc.want, o.headers)
 		})
@@ -47,7 +47,7 @@ func TestRedirect(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			o := &HtmxResponse{headers: make(map[string]string)}
-			tc.options.apply(o)
+			assert.NoError(t, tc.options.apply(o))
 
 			assert.Equal(t, tc.want, o.headers)
 		})
@@ -71,7 +71,7 @@ func TestRefresh(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			o := &HtmxResponse{headers: make(map[string]string)}
-			tc.options.apply(o)
+			assert.NoError(t, tc.options.apply(o))
 
 			assert.Equal(t, tc.want, o.headers)
 		})
@@ -95,7 +95,7 @@ func TestReplaceUrl(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			o := &HtmxResponse{headers: make(map[string]string)}
-			tc.options.apply(o)
+			assert.NoError(t, tc.options.apply(o))
 
 			assert.Equal(t, tc.want, o.headers)
 		})
@@ -119,7 +119,7 @@ func TestReselect(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			o := &HtmxResponse{headers: make(map[string]string)}
-			tc.options.apply(o)
+			assert.NoError(t, tc.options.apply(o))
 
 			assert.Equal(t, tc.want, o.headers)
 		})
@@ -143,7 +143,7 @@ func TestRetarget(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			o := &HtmxResponse{headers: make(map[string]string)}
-			tc.options.apply(o)
+			assert.NoError(t, tc.options.apply(o))
 
 			assert.Equal(t, tc.want, o.headers)
 		})
@@ -169,7 +169,7 @@ func TestStatus(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			o := &HtmxResponse{headers: make(map[string]string)}
-			tc.options.apply(o)
+			assert.NoError(t, tc.options.apply(o))
 
 			assert.Equal(t, tc.want, o.status)
 		})
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -80,16 +81,95 @@ func TestResponse(t *testing.T) {
 			},
 			wantStatus: http.StatusAccepted,
 		},
-		"Panics and recovers": {
+		"Strict with valid options": {
+			args: args{
+				options: []ResponseOption{
+					Strict(),
+					Location("/foo", Swap(SwapInnerHtml.Swap(time.Second))),
+					SwapOuterHtml.FocusScroll(true),
+				},
+			},
+			wantHeaders: http.Header{
+				HxLocation: []string{`{"path":"/foo","swap":"innerHTML swap:1s"}`},
+				HxReswap:   []string{`outerHTML focus-scroll:true`},
+			},
+			wantStatus: http.StatusOK,
+		},
+		"Strict with invalid reswap": {
+			args: args{
+				options: []ResponseOption{
+					Strict(),
+					Reswap("innerHtml"),
+				},
+			},
+			wantErr: fmt.Errorf(`Hx-Reswap: invalid swap "innerHtml": unknown swap style "innerHtml"`),
+		},
+		"Strict with invalid location swap": {
+			args: args{
+				options: []ResponseOption{
+					Location("/foo", Swap(SwapInnerHtml.Swap(-time.Second))),
+					Strict(),
+				},
+			},
+			wantErr: fmt.Errorf(`Hx-Location: invalid swap "innerHTML swap:-1s": modifier "swap:-1s": negative duration`),
+		},
+		"Not strict with invalid reswap": {
+			args: args{
+				options: []ResponseOption{
+					Reswap("innerHtml"),
+				},
+			},
+			wantHeaders: http.Header{
+				HxReswap: []string{`innerHtml`},
+			},
+			wantStatus: http.StatusOK,
+		},
+		"Accumulate trigger events": {
+			args: args{
+				options: []ResponseOption{
+					Trigger(Event("a")),
+					Trigger(Event("b", 1)),
+				},
+			},
+			wantHeaders: http.Header{
+				"Hx-Trigger": []string{`{"a":null,"b":1}`},
+			},
+			wantStatus: http.StatusOK,
+		},
+		"Duplicate trigger event": {
+			args: args{
+				options: []ResponseOption{
+					Trigger(Event("a", 1)),
+					Trigger(Event("a", 2)),
+				},
+			},
+			wantErr: fmt.Errorf(`HX-Trigger: duplicate event "a"`),
+		},
+		"Trigger with bad event data": {
 			args: args{
 				options: []ResponseOption{
 					Location("/foo"),
-					Trigger(func() map[string]any {
-						panic(fmt.Errorf("bad event data"))
-					}),
+					Trigger(Event("x", make(chan int))),
+				},
+			},
+			wantErr: fmt.Errorf(`HX-Trigger: event "x": json: unsupported type: chan int`),
+		},
+		"Location with bad values": {
+			args: args{
+				options: []ResponseOption{
+					Location("/foo", Values(make(chan int))),
 				},
 			},
-			wantErr: fmt.Errorf("bad event data"),
+			wantErr: fmt.Errorf(`Hx-Location: json: unsupported type: chan int`),
+		},
+		"Joins errors from options": {
+			args: args{
+				options: []ResponseOption{
+					Trigger(Event("x", make(chan int))),
+					TriggerAfterSettle(Event("y", func() {})),
+				},
+			},
+			wantErr: fmt.Errorf("HX-Trigger: event \"x\": json: unsupported type: chan int\nHx-Trigger-After-Settle: event \"y\": json: unsupported type: func()"),
 		},
 	}
 	for name, tt := range tests {
@@ -109,3 +189,54 @@ func TestResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestResponse_MergeTriggers(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		existing    string
+		options     []ResponseOption
+		wantTrigger string
+		wantErr     bool
+	}{
+		"Merge with earlier events": {
+			existing:    `{"session-refreshed":null}`,
+			options:     []ResponseOption{Trigger(Event("saved", 1))},
+			wantTrigger: `{"session-refreshed":null,"saved":1}`,
+		},
+		"Merge with comma separated events": {
+			existing:    "a, b",
+			options:     []ResponseOption{Trigger(Event("c"))},
+			wantTrigger: "a, b, c",
+		},
+		"Duplicate event": {
+			existing: `{"a":1}`,
+			options:  []ResponseOption{Trigger(Event("a", 2))},
+			wantErr:  true,
+		},
+		"Keep events without new events": {
+			existing:    `{"a":1}`,
+			options:     []ResponseOption{Reswap(SwapOuterHtml)},
+			wantTrigger: `{"a":1}`,
+		},
+		"Bad existing header": {
+			existing: `{"a":}`,
+			options:  []ResponseOption{Trigger(Event("b"))},
+			wantErr:  true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			wr := httptest.NewRecorder()
+			wr.Header().Set(HxTrigger, tt.existing)
+
+			err := Response(wr, tt.options...)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTrigger, wr.Header().Get(HxTrigger))
+		})
+	}
+}
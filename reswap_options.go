@@ -2,6 +2,7 @@ package hx
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -19,6 +20,7 @@ import (
 //   - SwapAfterEnd: Inserts the response after the target element.
 //   - SwapDelete: Deletes the target element, regardless of the response.
 //   - SwapNone: Does not append content from the response (out-of-band items will still be processed).
+//   - SwapTextContent: Replaces the text content of the target element without parsing the response as HTML.
 //
 // For more details, see: https://htmx.org/attributes/hx-swap
 //
@@ -38,7 +40,54 @@ import (
 //	// Sets HX-Reswap header to "innerHTML swap:1s settle:2s"
 type Reswap string
 
-func (s Reswap) apply(o *HtmxResponse) { o.headers[HxReswap] = string(s) }
+func (s Reswap) apply(o *HtmxResponse) error { return o.setHeader(HxReswap, string(s)) }
+
+// Validate checks the swap style and modifiers.
+//
+// An error is returned for unknown swap styles, duplicate modifiers, negative durations,
+// durations that htmx cannot read, such as "1m30s", and scroll or show targets that are not "top", "bottom", or "selector:top|bottom".
+// The show modifier also accepts "none".
+//
+// Use the Strict option to validate the HX-Reswap header when building a response.
+//
+// Example usage:
+//
+//	err := hx.Reswap("innerHtml").Validate()
+//	// Returns an error because the swap style is "innerHTML"
+func (s Reswap) Validate() error {
+	if _, err := parseReswap(string(s), true); err != nil {
+		return fmt.Errorf("invalid swap %q: %w", string(s), err)
+	}
+
+	return nil
+}
+
+// Spec parses the swap style and modifiers into a SwapSpec.
+//
+// If any of the modifiers cannot be parsed only the swap style is kept; use Validate to check for them.
+//
+// Example usage:
+//
+//	spec := hx.SwapInnerHtml.Swap(time.Second).Spec().Swap(2 * time.Second)
+//	// Renders "innerHTML swap:2s"
+func (s Reswap) Spec() SwapSpec {
+	spec, err := parseReswap(string(s), false)
+	if err == nil {
+		return *spec
+	}
+	if fields := strings.Fields(string(s)); len(fields) > 0 && !strings.Contains(fields[0], ":") {
+		return NewSwapSpec(Reswap(fields[0]))
+	}
+	return SwapSpec{}
+}
+
+func (s Reswap) known() bool {
+	switch s {
+	case SwapInnerHtml, SwapOuterHtml, SwapBeforeBegin, SwapAfterBegin, SwapBeforeEnd, SwapAfterEnd, SwapDelete, SwapNone, SwapTextContent:
+		return true
+	}
+	return false
+}
 
 // Reswap constants
 const (
@@ -58,6 +107,8 @@ const (
 	SwapDelete Reswap = "delete"
 	// SwapNone does not append content from response (out of band items will still be processed)
 	SwapNone Reswap = "none"
+	// SwapTextContent replace the text content of the target element without parsing the response as HTML
+	SwapTextContent Reswap = "textContent"
 )
 
 // Transition (reswap header modifier) allows you to specify the use of the View Transition API when a swap occurs
@@ -74,6 +125,8 @@ func (s Reswap) Transition() Reswap {
 
 // Swap (reswap header modifier) is used to set a time wait after receiving a response before swapping the content
 //
+// A negative duration is kept in the header, where Validate and the Strict option reject it.
+//
 // More details: https://htmx.org/attributes/hx-swap/#timing-swap-settle
 //
 // Example usage:
@@ -81,11 +134,13 @@ func (s Reswap) Transition() Reswap {
 //	hx.Response(w, hx.SwapInnerHtml.Swap(1*time.Second))
 //	// Sets HX-Reswap header to "innerHTML swap:1s"
 func (s Reswap) Swap(dur time.Duration) Reswap {
-	return Reswap(fmt.Sprintf("%s swap:%s", s, dur))
+	return Reswap(string(s) + " swap:" + formatInterval(dur))
 }
 
 // Settle (reswap header modifier) is used to set a time to wait after swapping before triggering the settle step
 //
+// A negative duration is kept in the header, where Validate and the Strict option reject it.
+//
 // More details: https://htmx.org/attributes/hx-swap/#timing-swap-settle
 //
 // Example usage:
@@ -93,7 +148,7 @@ func (s Reswap) Swap(dur time.Duration) Reswap {
 //	hx.Response(w, hx.SwapInnerHtml.Settle(1*time.Second))
 //	// Sets HX-Reswap header to "innerHTML settle:1s"
 func (s Reswap) Settle(dur time.Duration) Reswap {
-	return Reswap(fmt.Sprintf("%s settle:%s", s, dur))
+	return Reswap(string(s) + " settle:" + formatInterval(dur))
 }
 
 // IgnoreTitle (reswap header modifier) is used to ignore any <title> tags in the response
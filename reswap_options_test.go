@@ -103,7 +103,17 @@ func TestReswap_Settle(t *testing.T) {
 		"Set settle with 1m30s": {
 			s:    SwapInnerHtml,
 			args: args{dur: 1*time.Minute + 30*time.Second},
-			want: Reswap("innerHTML settle:1m30s"),
+			want: Reswap("innerHTML settle:90s"),
+		},
+		"Set settle with 1500ms": {
+			s:    SwapInnerHtml,
+			args: args{dur: 1500 * time.Millisecond},
+			want: Reswap("innerHTML settle:1500ms"),
+		},
+		"Set settle with sub-millisecond precision": {
+			s:    SwapInnerHtml,
+			args: args{dur: 1500 * time.Microsecond},
+			want: Reswap("innerHTML settle:2ms"),
 		},
 	}
 	for name, tt := range tests {
@@ -161,7 +171,17 @@ func TestReswap_Swap(t *testing.T) {
 		"Set swap with 1m30s": {
 			s:    SwapInnerHtml,
 			args: args{dur: 1*time.Minute + 30*time.Second},
-			want: Reswap("innerHTML swap:1m30s"),
+			want: Reswap("innerHTML swap:90s"),
+		},
+		"Set swap with 1500ms": {
+			s:    SwapInnerHtml,
+			args: args{dur: 1500 * time.Millisecond},
+			want: Reswap("innerHTML swap:1500ms"),
+		},
+		"Set swap with sub-millisecond precision": {
+			s:    SwapInnerHtml,
+			args: args{dur: 1500 * time.Microsecond},
+			want: Reswap("innerHTML swap:2ms"),
 		},
 	}
 	for name, tt := range tests {
@@ -405,3 +425,60 @@ func TestSwapNone(t *testing.T) {
 		})
 	}
 }
+
+func TestReswap_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		s       Reswap
+		wantErr string
+	}{
+		"Valid style": {
+			s: SwapInnerHtml,
+		},
+		"Valid modifiers": {
+			s: SwapOuterHtml.Transition().Swap(time.Second).Settle(0).IgnoreTitle().Scroll("#foo:top").Show("bottom").FocusScroll(true),
+		},
+		"Modifiers only": {
+			s: Reswap("swap:1s"),
+		},
+		"Wrong case": {
+			s:       Reswap("innerHtml"),
+			wantErr: `invalid swap "innerHtml": unknown swap style "innerHtml"`,
+		},
+		"Duplicate modifier": {
+			s:       SwapInnerHtml.Swap(time.Second).Swap(2 * time.Second),
+			wantErr: `invalid swap "innerHTML swap:1s swap:2s": duplicate modifier "swap"`,
+		},
+		"Conflicting modifier": {
+			s:       SwapInnerHtml.FocusScroll(true).FocusScroll(false),
+			wantErr: `invalid swap "innerHTML focus-scroll:true focus-scroll:false": duplicate modifier "focus-scroll"`,
+		},
+		"Negative duration": {
+			s:       SwapInnerHtml.Settle(-time.Second),
+			wantErr: `invalid swap "innerHTML settle:-1s": modifier "settle:-1s": negative duration`,
+		},
+		"Bad scroll target": {
+			s:       SwapInnerHtml.Scroll("#foo"),
+			wantErr: `invalid swap "innerHTML scroll:#foo": modifier "scroll:#foo": target must end with "top" or "bottom"`,
+		},
+		"Missing show selector": {
+			s:       SwapInnerHtml.Show(":top"),
+			wantErr: `invalid swap "innerHTML show::top": modifier "show::top": missing selector`,
+		},
+		"Unknown modifier": {
+			s:       Reswap("innerHTML foo:bar"),
+			wantErr: `invalid swap "innerHTML foo:bar": modifier "foo:bar": unknown modifier`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.s.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
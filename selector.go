@@ -0,0 +1,113 @@
+package hx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Selector is a CSS selector, or one of the extended selectors of htmx such as "closest tr".
+//
+// Retarget, Reselect, Target, Source, Select, OOBSwap, and EventOn accept either a string or a Selector.
+// The selector methods of TriggerSpec, SwapSpec, and TriggerEvent take a Selector, which also
+// accepts untyped string constants such as "#list".
+// Use the constructors to build selectors instead of concatenating strings, so that
+// IDs and class names are escaped correctly.
+//
+// Example usage:
+//
+//	hx.Response(w, hx.Retarget(hx.ID("user-42.5")))
+//	// Sets the HX-Retarget header to "#user-42\.5".
+//
+//	hx.Location("/users", hx.Target(hx.Closest("tr")))
+//	// Sets the 'target' property of the HX-Location header to "closest tr"
+type Selector string
+
+// Extended selectors
+const (
+	// This is the element itself
+	This Selector = "this"
+	// Document is the document
+	Document Selector = "document"
+	// Window is the window
+	Window Selector = "window"
+)
+
+// ID creates a selector for the element with the ID.
+//
+// The id must not be empty; ID("") returns "#", which is not a valid selector.
+//
+// Example usage:
+//
+//	hx.ID("user-42")
+//	// Returns "#user-42"
+func ID(id string) Selector {
+	return Selector("#" + cssEscape(id))
+}
+
+// Class creates a selector for the elements with the class name.
+//
+// The name must not be empty; Class("") returns ".", which is not a valid selector.
+//
+// Example usage:
+//
+//	hx.Class("2col")
+//	// Returns ".\32 col"
+func Class(name string) Selector {
+	return Selector("." + cssEscape(name))
+}
+
+// Closest creates an extended selector for the closest ancestor, or the element itself, matching the selector.
+//
+// Example usage:
+//
+//	hx.Closest("tr")
+//	// Returns "closest tr"
+func Closest[T string | Selector](selector T) Selector {
+	return Selector("closest " + string(selector))
+}
+
+// Find creates an extended selector for the first child descendant matching the selector.
+func Find[T string | Selector](selector T) Selector {
+	return Selector("find " + string(selector))
+}
+
+// Next creates an extended selector for the next element in the DOM matching the selector.
+func Next[T string | Selector](selector T) Selector {
+	return Selector("next " + string(selector))
+}
+
+// Previous creates an extended selector for the previous element in the DOM matching the selector.
+func Previous[T string | Selector](selector T) Selector {
+	return Selector("previous " + string(selector))
+}
+
+// String returns the selector.
+func (s Selector) String() string { return string(s) }
+
+// cssEscape escapes the identifier following the CSS.escape() algorithm
+//
+// See https://drafts.csswg.org/cssom/#serialize-an-identifier
+func cssEscape(ident string) string {
+	var b strings.Builder
+	runes := []rune(ident)
+	for i, c := range runes {
+		switch {
+		case c == 0:
+			b.WriteRune('\uFFFD')
+		case c <= 0x1f || c == 0x7f,
+			i == 0 && c >= '0' && c <= '9',
+			i == 1 && c >= '0' && c <= '9' && runes[0] == '-':
+			fmt.Fprintf(&b, "\\%x ", c)
+		case i == 0 && c == '-' && len(runes) == 1:
+			b.WriteString("\\-")
+		case c >= 0x80, c == '-', c == '_',
+			c >= '0' && c <= '9', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+			b.WriteRune(c)
+		default:
+			b.WriteRune('\\')
+			b.WriteRune(c)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package hx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelector(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		selector Selector
+		want     string
+	}{
+		"ID":                        {selector: ID("user-42"), want: "#user-42"},
+		"ID with a dot":             {selector: ID("user-42.5"), want: `#user-42\.5`},
+		"ID starting with a digit":  {selector: ID("42"), want: `#\34 2`},
+		"ID starting with -digit":   {selector: ID("-4x"), want: `#-\34 x`},
+		"ID of a single dash":       {selector: ID("-"), want: `#\-`},
+		"ID with special chars":     {selector: ID("a b:c[d]"), want: `#a\ b\:c\[d\]`},
+		"ID with control character": {selector: ID("a\x01b\x00"), want: "#a\\1 b�"},
+		"ID with unicode":           {selector: ID("café_1"), want: "#café_1"},
+		"Empty ID":                  {selector: ID(""), want: "#"},
+		"Class":                     {selector: Class("2col"), want: `.\32 col`},
+		"Empty class":               {selector: Class(""), want: "."},
+		"Closest":                   {selector: Closest("tr"), want: "closest tr"},
+		"Closest class":             {selector: Closest(Class("row")), want: "closest .row"},
+		"Find":                      {selector: Find(ID("name")), want: "find #name"},
+		"Next":                      {selector: Next(".item"), want: "next .item"},
+		"Previous":                  {selector: Previous("li"), want: "previous li"},
+		"This":                      {selector: This, want: "this"},
+		"Document":                  {selector: Document, want: "document"},
+		"Window":                    {selector: Window, want: "window"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.selector.String())
+		})
+	}
+}
+
+func TestSelector_Options(t *testing.T) {
+	t.Parallel()
+
+	o, err := BuildResponse(
+		Retarget(Closest("tr")),
+		Reselect(ID("user-42.5")),
+		Location("/users", Source(This), Target(Find(".list")), Select(Class("main"))),
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		HxRetarget: "closest tr",
+		HxReselect: `#user-42\.5`,
+		HxLocation: `{"path":"/users","source":"this","target":"find .list","select":".main"}`,
+	}, o.headers)
+}
+
+func TestSelector_Specs(t *testing.T) {
+	t.Parallel()
+
+	id := ID("user-42.5")
+
+	assert.Equal(t, `click from:closest .row target:#user-42\.5`, NewTriggerSpec("click").From(Closest(Class("row"))).Target(id).String())
+	assert.Equal(t, "intersect root:#list", NewTriggerSpec(TriggerIntersect).Root(ID("list")).String())
+	assert.Equal(t, `beforeend scroll:#user-42\.5:bottom show:.main:top`, NewSwapSpec(SwapBeforeEnd).ScrollElement(id, ScrollBottom).ShowElement(Class("main"), ScrollTop).String())
+
+	value, err := OOBSwap(SwapBeforeEnd, id, nil).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `beforeend:#user-42\.5`, value)
+
+	o, err := BuildResponse(Trigger(EventOn(id, "a"), Event("b", 1).Target(Class("c"))))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		HxTrigger: `{"a":{"target":"#user-42\\.5"},"b":{"target":".c","value":1}}`,
+	}, o.headers)
+}
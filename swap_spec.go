@@ -0,0 +1,327 @@
+package hx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ScrollDirection is the direction used by the scroll and show swap modifiers.
+type ScrollDirection string
+
+// ScrollDirection constants
+const (
+	// ScrollTop scrolls to the top of the element
+	ScrollTop ScrollDirection = "top"
+	// ScrollBottom scrolls to the bottom of the element
+	ScrollBottom ScrollDirection = "bottom"
+)
+
+// SwapSpec is a swap style along with its modifiers.
+//
+// Unlike Reswap, which appends each modifier to a string, a SwapSpec keeps every
+// modifier in its own field. Setting a modifier twice replaces the earlier value,
+// the values can be read back, and the spec always renders its modifiers in the
+// same order: transition, swap, settle, ignoreTitle, scroll, show, focus-scroll.
+//
+// A SwapSpec can be used as a response option to set the HX-Reswap header, and with
+// the Swap property of the HX-Location header.
+//
+// Example usage:
+//
+//	spec := hx.NewSwapSpec(hx.SwapInnerHtml).Settle(time.Second).ShowWindow(hx.ScrollTop)
+//	hx.Response(w, spec)
+//	// Sets HX-Reswap header to "innerHTML settle:1s show:window:top"
+type SwapSpec struct {
+	style       Reswap
+	transition  *bool
+	swap        *time.Duration
+	settle      *time.Duration
+	ignoreTitle *bool
+	scroll      string
+	show        string
+	focusScroll *bool
+}
+
+// NewSwapSpec creates a SwapSpec for the swap style.
+//
+// The style may be empty to only set modifiers and use the default swap style of the client.
+func NewSwapSpec(style Reswap) SwapSpec {
+	return SwapSpec{style: style}
+}
+
+func (s SwapSpec) apply(o *HtmxResponse) error { return o.setHeader(HxReswap, s.String()) }
+
+// Transition enables the use of the View Transition API when the swap occurs.
+func (s SwapSpec) Transition() SwapSpec {
+	s.transition = boolPtr(true)
+	return s
+}
+
+// Swap sets the time to wait after receiving a response before swapping the content.
+func (s SwapSpec) Swap(dur time.Duration) SwapSpec {
+	s.swap = &dur
+	return s
+}
+
+// Settle sets the time to wait after swapping before triggering the settle step.
+func (s SwapSpec) Settle(dur time.Duration) SwapSpec {
+	s.settle = &dur
+	return s
+}
+
+// IgnoreTitle ignores any <title> tags in the response.
+func (s SwapSpec) IgnoreTitle() SwapSpec {
+	s.ignoreTitle = boolPtr(true)
+	return s
+}
+
+// Scroll scrolls the target element to the top or bottom after swapping.
+//
+// Example usage:
+//
+//	hx.NewSwapSpec(hx.SwapBeforeEnd).Scroll(hx.ScrollBottom)
+//	// Renders "beforeend scroll:bottom"
+func (s SwapSpec) Scroll(direction ScrollDirection) SwapSpec {
+	s.scroll = string(direction)
+	return s
+}
+
+// ScrollElement scrolls the selected element to the top or bottom after swapping.
+//
+// htmx splits the hx-swap value on whitespace, so the selector must not contain any. Extended
+// selectors such as hx.Closest("tr") cannot be used here, and Validate reports them.
+//
+// Example usage:
+//
+//	hx.NewSwapSpec(hx.SwapBeforeEnd).ScrollElement("#messages", hx.ScrollBottom)
+//	// Renders "beforeend scroll:#messages:bottom"
+func (s SwapSpec) ScrollElement(selector Selector, direction ScrollDirection) SwapSpec {
+	s.scroll = string(selector) + ":" + string(direction)
+	return s
+}
+
+// Show scrolls the top or bottom of the target element into view after swapping.
+func (s SwapSpec) Show(direction ScrollDirection) SwapSpec {
+	s.show = string(direction)
+	return s
+}
+
+// ShowElement scrolls the top or bottom of the selected element into view after swapping.
+//
+// As with ScrollElement, the selector must not contain whitespace.
+func (s SwapSpec) ShowElement(selector Selector, direction ScrollDirection) SwapSpec {
+	s.show = string(selector) + ":" + string(direction)
+	return s
+}
+
+// ShowWindow scrolls to the top or bottom of the window after swapping.
+//
+// Example usage:
+//
+//	hx.NewSwapSpec(hx.SwapInnerHtml).ShowWindow(hx.ScrollTop)
+//	// Renders "innerHTML show:window:top"
+func (s SwapSpec) ShowWindow(direction ScrollDirection) SwapSpec {
+	return s.ShowElement("window", direction)
+}
+
+// ShowNone disables showing the target element after swapping.
+func (s SwapSpec) ShowNone() SwapSpec {
+	s.show = "none"
+	return s
+}
+
+// FocusScroll enables or disables scrolling to the focused element after swapping.
+func (s SwapSpec) FocusScroll(focus bool) SwapSpec {
+	s.focusScroll = &focus
+	return s
+}
+
+// Style returns the swap style.
+func (s SwapSpec) Style() Reswap { return s.style }
+
+// SwapDelay returns the swap delay and whether it was set.
+func (s SwapSpec) SwapDelay() (time.Duration, bool) { return durationValue(s.swap) }
+
+// SettleDelay returns the settle delay and whether it was set.
+func (s SwapSpec) SettleDelay() (time.Duration, bool) { return durationValue(s.settle) }
+
+// HasTransition returns true if the View Transition API will be used.
+func (s SwapSpec) HasTransition() bool { return s.transition != nil && *s.transition }
+
+// HasIgnoreTitle returns true if <title> tags in the response will be ignored.
+func (s SwapSpec) HasIgnoreTitle() bool { return s.ignoreTitle != nil && *s.ignoreTitle }
+
+// ScrollTarget returns the value of the scroll modifier, or an empty string if it was not set.
+func (s SwapSpec) ScrollTarget() string { return s.scroll }
+
+// ShowTarget returns the value of the show modifier, or an empty string if it was not set.
+func (s SwapSpec) ShowTarget() string { return s.show }
+
+// FocusScrollEnabled returns the value of the focus-scroll modifier and whether it was set.
+func (s SwapSpec) FocusScrollEnabled() (bool, bool) {
+	if s.focusScroll == nil {
+		return false, false
+	}
+	return *s.focusScroll, true
+}
+
+// String renders the swap style and modifiers in canonical order.
+func (s SwapSpec) String() string {
+	var parts []string
+	if s.style != "" {
+		parts = append(parts, string(s.style))
+	}
+	if s.transition != nil {
+		parts = append(parts, fmt.Sprintf("transition:%t", *s.transition))
+	}
+	if s.swap != nil {
+		parts = append(parts, "swap:"+formatInterval(*s.swap))
+	}
+	if s.settle != nil {
+		parts = append(parts, "settle:"+formatInterval(*s.settle))
+	}
+	if s.ignoreTitle != nil {
+		parts = append(parts, fmt.Sprintf("ignoreTitle:%t", *s.ignoreTitle))
+	}
+	if s.scroll != "" {
+		parts = append(parts, "scroll:"+s.scroll)
+	}
+	if s.show != "" {
+		parts = append(parts, "show:"+s.show)
+	}
+	if s.focusScroll != nil {
+		parts = append(parts, fmt.Sprintf("focus-scroll:%t", *s.focusScroll))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Reswap returns the rendered spec as a Reswap.
+func (s SwapSpec) Reswap() Reswap {
+	return Reswap(s.String())
+}
+
+// Validate checks the swap style and modifiers.
+//
+// See Reswap.Validate for more details. Durations that are not a whole number of
+// milliseconds are also rejected, because they are rounded when the spec is rendered, and
+// so are scroll and show selectors with whitespace, which htmx would split into other modifiers.
+func (s SwapSpec) Validate() error {
+	if err := checkIntervals(s.swap, s.settle); err != nil {
+		return fmt.Errorf("invalid swap %q: %w", s.String(), err)
+	}
+	if hasSpace(s.scroll) {
+		return fmt.Errorf("invalid swap %q: the scroll selector must not contain whitespace", s.String())
+	}
+	if hasSpace(s.show) {
+		return fmt.Errorf("invalid swap %q: the show selector must not contain whitespace", s.String())
+	}
+	return s.Reswap().Validate()
+}
+
+// hasSpace reports whether the value would be split by strings.Fields
+func hasSpace(value string) bool {
+	return strings.IndexFunc(value, unicode.IsSpace) >= 0
+}
+
+// parseReswap decodes the swap style and modifiers
+//
+// In strict mode unknown swap styles, duplicate modifiers, negative durations, and
+// invalid scroll or show targets are rejected.
+func parseReswap(value string, strict bool) (*SwapSpec, error) {
+	fields := strings.Fields(value)
+	s := &SwapSpec{}
+	if len(fields) == 0 {
+		return s, nil
+	}
+
+	if !strings.Contains(fields[0], ":") {
+		s.style = Reswap(fields[0])
+		fields = fields[1:]
+		if strict && !s.style.known() {
+			return nil, fmt.Errorf("unknown swap style %q", s.style)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		name, arg, found := strings.Cut(field, ":")
+		if !found {
+			return nil, fmt.Errorf("unexpected value %q", field)
+		}
+		if strict && seen[name] {
+			return nil, fmt.Errorf("duplicate modifier %q", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "transition":
+			s.transition, err = parseBoolPtr(arg)
+		case "swap":
+			s.swap, err = parseIntervalPtr(arg, strict)
+		case "settle":
+			s.settle, err = parseIntervalPtr(arg, strict)
+		case "ignoreTitle":
+			s.ignoreTitle, err = parseBoolPtr(arg)
+		case "scroll":
+			s.scroll = arg
+			if strict {
+				err = validateScrollTarget(arg, false)
+			}
+		case "show":
+			s.show = arg
+			if strict {
+				err = validateScrollTarget(arg, true)
+			}
+		case "focus-scroll":
+			s.focusScroll, err = parseBoolPtr(arg)
+		default:
+			err = fmt.Errorf("unknown modifier")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("modifier %q: %w", field, err)
+		}
+	}
+
+	return s, nil
+}
+
+// validateScrollTarget accepts "top", "bottom", or "selector:top|bottom"; show also accepts "none"
+func validateScrollTarget(target string, allowNone bool) error {
+	if allowNone && target == "none" {
+		return nil
+	}
+	direction := target
+	if i := strings.LastIndex(target, ":"); i >= 0 {
+		if i == 0 {
+			return fmt.Errorf("missing selector")
+		}
+		direction = target[i+1:]
+	}
+	if ScrollDirection(direction) != ScrollTop && ScrollDirection(direction) != ScrollBottom {
+		return fmt.Errorf("target must end with \"top\" or \"bottom\"")
+	}
+
+	return nil
+}
+
+func parseBoolPtr(value string) (*bool, error) {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func durationValue(d *time.Duration) (time.Duration, bool) {
+	if d == nil {
+		return 0, false
+	}
+	return *d, true
+}
+
+func boolPtr(b bool) *bool { return &b }
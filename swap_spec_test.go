@@ -0,0 +1,160 @@
+package hx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwapSpec_String(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		spec SwapSpec
+		want string
+	}{
+		"Style only": {
+			spec: NewSwapSpec(SwapTextContent),
+			want: "textContent",
+		},
+		"Modifiers only": {
+			spec: NewSwapSpec("").Settle(time.Second),
+			want: "settle:1s",
+		},
+		"Canonical order": {
+			spec: NewSwapSpec(SwapInnerHtml).
+				FocusScroll(true).
+				ShowWindow(ScrollTop).
+				ScrollElement("#list", ScrollBottom).
+				IgnoreTitle().
+				Settle(2 * time.Second).
+				Swap(time.Second).
+				Transition(),
+			want: "innerHTML transition:true swap:1s settle:2s ignoreTitle:true scroll:#list:bottom show:window:top focus-scroll:true",
+		},
+		"Modifiers replace each other": {
+			spec: NewSwapSpec(SwapInnerHtml).
+				Swap(time.Second).
+				Swap(2*time.Second).
+				Scroll(ScrollTop).
+				ScrollElement("#list", ScrollBottom).
+				FocusScroll(true).
+				FocusScroll(false),
+			want: "innerHTML swap:2s scroll:#list:bottom focus-scroll:false",
+		},
+		"Show none": {
+			spec: NewSwapSpec(SwapOuterHtml).Show(ScrollTop).ShowNone(),
+			want: "outerHTML show:none",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.spec.String())
+			assert.NoError(t, tt.spec.Validate())
+
+			parsed, err := ParseReswap(tt.spec.String())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.spec, *parsed)
+		})
+	}
+}
+
+func TestSwapSpec_Getters(t *testing.T) {
+	t.Parallel()
+
+	spec := NewSwapSpec(SwapBeforeEnd).Settle(time.Second).ShowElement("#foo", ScrollBottom).FocusScroll(false)
+
+	assert.Equal(t, SwapBeforeEnd, spec.Style())
+	settle, ok := spec.SettleDelay()
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, settle)
+	_, ok = spec.SwapDelay()
+	assert.False(t, ok)
+	assert.False(t, spec.HasTransition())
+	assert.False(t, spec.HasIgnoreTitle())
+	assert.Equal(t, "", spec.ScrollTarget())
+	assert.Equal(t, "#foo:bottom", spec.ShowTarget())
+	focus, ok := spec.FocusScrollEnabled()
+	assert.True(t, ok)
+	assert.False(t, focus)
+}
+
+func TestSwapSpec_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		spec    SwapSpec
+		wantErr string
+	}{
+		"Valid": {
+			spec: NewSwapSpec(SwapInnerHtml).Swap(0),
+		},
+		"Unknown style": {
+			spec:    NewSwapSpec("innerHtml"),
+			wantErr: `invalid swap "innerHtml": unknown swap style "innerHtml"`,
+		},
+		"Negative duration": {
+			spec:    NewSwapSpec(SwapInnerHtml).Swap(-time.Second),
+			wantErr: `invalid swap "innerHTML swap:-1s": modifier "swap:-1s": negative duration`,
+		},
+		"Extended scroll selector": {
+			spec:    NewSwapSpec(SwapInnerHtml).ScrollElement(Closest("tr"), ScrollTop),
+			wantErr: `invalid swap "innerHTML scroll:closest tr:top": the scroll selector must not contain whitespace`,
+		},
+		"Show selector with whitespace": {
+			spec:    NewSwapSpec(SwapInnerHtml).ShowElement("#list .item", ScrollBottom),
+			wantErr: `invalid swap "innerHTML show:#list .item:bottom": the show selector must not contain whitespace`,
+		},
+		"Bad direction": {
+			spec:    NewSwapSpec(SwapInnerHtml).Scroll("middle"),
+			wantErr: `invalid swap "innerHTML scroll:middle": modifier "scroll:middle": target must end with "top" or "bottom"`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestReswap_Spec(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		s    Reswap
+		want string
+	}{
+		"Replace modifier": {
+			s:    SwapInnerHtml.Swap(time.Second),
+			want: "innerHTML swap:2s",
+		},
+		"Unparsable modifiers": {
+			s:    Reswap("innerHTML foo:bar"),
+			want: "innerHTML swap:2s",
+		},
+		"Unparsable modifiers only": {
+			s:    Reswap("foo:bar"),
+			want: "swap:2s",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.s.Spec().Swap(2*time.Second).String())
+		})
+	}
+}
+
+func TestSwapSpec_apply(t *testing.T) {
+	t.Parallel()
+
+	o := &HtmxResponse{headers: make(map[string]string)}
+	assert.NoError(t, NewSwapSpec(SwapOuterHtml).Transition().apply(o))
+
+	assert.Equal(t, map[string]string{HxReswap: "outerHTML transition:true"}, o.headers)
+}
@@ -22,7 +22,7 @@ const (
 // Simple example:
 //
 //	hx.Response(w, hx.Trigger(hx.Event("myEvent")))
-//	// Sets HX-Trigger header to {"myEvent":null}
+//	// Sets HX-Trigger header to myEvent
 //
 // Example with data:
 //
@@ -37,11 +37,17 @@ const (
 //	))
 //	// Sets HX-Trigger header to {"myEvent":"myData","myOtherEvent":"myOtherData"}
 //
+// The events are sent in the order they were added. When none of the events have any data,
+// the shorter comma separated form is used, for example "myEvent, myOtherEvent".
+//
+// Events accumulate: using Trigger more than once, or calling Response again on the same
+// http.ResponseWriter, adds to the events that have already been set. Using the same event
+// name more than once in a header returns an error.
+//
 // See also: TriggerAfterSettle and TriggerAfterSwap
-func Trigger(events ...event) responseOptionFunc {
-	return func(o *HtmxResponse) {
-		data := triggeredEvents(events)
-		o.headers[HxTrigger] = string(data)
+func Trigger(events ...TriggerEvent) responseOptionFunc {
+	return func(o *HtmxResponse) error {
+		return o.addEvents(HxTrigger, events)
 	}
 }
 
@@ -50,10 +56,9 @@ func Trigger(events ...event) responseOptionFunc {
 // More details: https://htmx.org/reference/#response_headers
 //
 // For more details, see: Trigger
-func TriggerAfterSettle(events ...event) responseOptionFunc {
-	return func(o *HtmxResponse) {
-		data := triggeredEvents(events)
-		o.headers[HxTriggerAfterSettle] = string(data)
+func TriggerAfterSettle(events ...TriggerEvent) responseOptionFunc {
+	return func(o *HtmxResponse) error {
+		return o.addEvents(HxTriggerAfterSettle, events)
 	}
 }
 
@@ -62,10 +67,9 @@ func TriggerAfterSettle(events ...event) responseOptionFunc {
 // More details: https://htmx.org/reference/#response_headers
 //
 // For more details, see: Trigger
-func TriggerAfterSwap(events ...event) responseOptionFunc {
-	return func(o *HtmxResponse) {
-		data := triggeredEvents(events)
-		o.headers[HxTriggerAfterSwap] = string(data)
+func TriggerAfterSwap(events ...TriggerEvent) responseOptionFunc {
+	return func(o *HtmxResponse) error {
+		return o.addEvents(HxTriggerAfterSwap, events)
 	}
 }
 
@@ -90,7 +94,7 @@ func TriggerAfterSwap(events ...event) responseOptionFunc {
 //
 //	hx.Event("myEvent", map[string]string{"myKey": "myValue"})
 //	// Returns {"myEvent":{"myKey":"myValue"}}
-func Event(name string, data ...any) event {
+func Event(name string, data ...any) TriggerEvent {
 	return func() map[string]any {
 		switch len(data) {
 		case 0:
@@ -102,3 +106,33 @@ func Event(name string, data ...any) event {
 		}
 	}
 }
+
+// Target returns a copy of the event that is triggered on the element matching the selector.
+//
+// The data of the event is sent as the 'value' of the event object and is left out when there is no data.
+//
+// Example usage:
+//
+//	hx.Event("showMessage", "Saved").Target("#messages")
+//	// Returns {"showMessage":{"target":"#messages","value":"Saved"}}
+func (e TriggerEvent) Target(selector Selector) TriggerEvent {
+	return func() map[string]any {
+		events := make(map[string]any)
+		for name, data := range e() {
+			events[name] = targetedEvent{Target: string(selector), Value: data}
+		}
+		return events
+	}
+}
+
+// EventOn creates an event that is triggered on the element matching the selector.
+//
+// Example usage:
+//
+//	hx.EventOn("#messages", "showMessage", "Saved")
+//	// Returns {"showMessage":{"target":"#messages","value":"Saved"}}
+//
+// See also: Event and TriggerEvent.Target
+func EventOn[T string | Selector](selector T, name string, data ...any) TriggerEvent {
+	return Event(name, data...).Target(Selector(selector))
+}
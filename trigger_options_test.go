@@ -2,6 +2,7 @@ package hx
 
 import (
 	"encoding/json"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -61,6 +62,23 @@ func TestTrigger(t *testing.T) {
 				},
 			},
 		},
+		"Set event on target": {
+			trigger: Trigger(Event("myEvent", "myValue").Target("#other")),
+			want: map[string]any{
+				"myEvent": map[string]any{
+					"target": "#other",
+					"value":  "myValue",
+				},
+			},
+		},
+		"Set event on target without value": {
+			trigger: Trigger(EventOn("#other", "myEvent")),
+			want: map[string]any{
+				"myEvent": map[string]any{
+					"target": "#other",
+				},
+			},
+		},
 		"Set multiple events": {
 			trigger: Trigger(
 				Event("myEvent"),
@@ -89,12 +107,11 @@ func TestTrigger(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			o := &HtmxResponse{headers: make(map[string]string)}
-			tc.trigger.apply(o)
+			assert.NoError(t, tc.trigger.apply(o))
 
 			gotHeader := o.headers[HxTrigger]
 			assert.NotEmpty(t, gotHeader)
-			got := make(map[string]any)
-			assert.NoError(t, json.Unmarshal([]byte(gotHeader), &got))
+			got := decodeTriggers(t, gotHeader)
 			assert.Equal(t, tc.want, got)
 		})
 	}
@@ -182,12 +199,11 @@ func TestTriggerAfterSettle(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			o := &HtmxResponse{headers: make(map[string]string)}
-			tc.trigger.apply(o)
+			assert.NoError(t, tc.trigger.apply(o))
 
 			gotHeader := o.headers[HxTriggerAfterSettle]
 			assert.NotEmpty(t, gotHeader)
-			got := make(map[string]any)
-			assert.NoError(t, json.Unmarshal([]byte(gotHeader), &got))
+			got := decodeTriggers(t, gotHeader)
 			assert.Equal(t, tc.want, got)
 		})
 	}
@@ -275,13 +291,79 @@ func TestTriggerAfterSwap(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			o := &HtmxResponse{headers: make(map[string]string)}
-			tc.trigger.apply(o)
+			assert.NoError(t, tc.trigger.apply(o))
 
 			gotHeader := o.headers[HxTriggerAfterSwap]
 			assert.NotEmpty(t, gotHeader)
-			got := make(map[string]any)
-			assert.NoError(t, json.Unmarshal([]byte(gotHeader), &got))
+			got := decodeTriggers(t, gotHeader)
 			assert.Equal(t, tc.want, got)
 		})
 	}
 }
+
+func TestTrigger_Order(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		options []ResponseOption
+		want    string
+		wantErr bool
+	}{
+		"Comma separated names": {
+			options: []ResponseOption{Trigger(Event("b"), Event("a")), Trigger(Event("c"))},
+			want:    "b, a, c",
+		},
+		"JSON keeps order": {
+			options: []ResponseOption{Trigger(Event("b", 1), Event("a")), Trigger(Event("c", "x"))},
+			want:    `{"b":1,"a":null,"c":"x"}`,
+		},
+		"Names that need JSON": {
+			options: []ResponseOption{Trigger(Event("a b"))},
+			want:    `{"a b":null}`,
+		},
+		"Duplicate name": {
+			options: []ResponseOption{Trigger(Event("a")), Trigger(Event("a", 1))},
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			o, err := BuildResponse(tc.options...)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, o.headers[HxTrigger])
+		})
+	}
+}
+
+func TestTrigger_NoEvents(t *testing.T) {
+	t.Parallel()
+
+	o, err := BuildResponse(Trigger(), TriggerAfterSettle(), TriggerAfterSwap(Event("a")))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{HxTriggerAfterSwap: "a"}, o.headers)
+
+	wr := httptest.NewRecorder()
+	assert.NoError(t, Response(wr, Trigger()))
+	assert.NotContains(t, wr.Header(), HxTrigger)
+}
+
+// decodeTriggers decodes either form of a trigger header into a map of the event names to their data
+func decodeTriggers(t *testing.T, value string) map[string]any {
+	t.Helper()
+
+	events, err := parseTriggerValue(value)
+	assert.NoError(t, err)
+	got := make(map[string]any)
+	for _, event := range events {
+		var data any
+		if event.Data != nil {
+			assert.NoError(t, json.Unmarshal(event.Data, &data))
+		}
+		got[event.Name] = data
+	}
+	return got
+}
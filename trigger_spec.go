@@ -0,0 +1,452 @@
+package hx
+
+import (
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Trigger events with special handling by htmx
+const (
+	// TriggerLoad triggers when the element is loaded
+	TriggerLoad = "load"
+	// TriggerRevealed triggers when the element is scrolled into the viewport
+	TriggerRevealed = "revealed"
+	// TriggerIntersect triggers once when the element first intersects the viewport
+	TriggerIntersect = "intersect"
+)
+
+// QueueOption determines how events are queued while a request is in flight.
+type QueueOption string
+
+// QueueOption constants
+const (
+	// QueueFirst queues the first event
+	QueueFirst QueueOption = "first"
+	// QueueLast queues the last event
+	QueueLast QueueOption = "last"
+	// QueueAll queues all events
+	QueueAll QueueOption = "all"
+	// QueueNone does not queue new events
+	QueueNone QueueOption = "none"
+)
+
+// TriggerSpec is a single trigger of an hx-trigger attribute, made of an event or polling
+// interval, an optional filter, and the modifiers.
+//
+// For more details, see: https://htmx.org/attributes/hx-trigger
+//
+// Setting a modifier twice replaces the earlier value, and the modifiers are always rendered
+// in the same order: once, changed, delay, throttle, from, target, consume, queue, root, threshold.
+//
+// Use TriggerSpecs to combine several triggers in one attribute.
+//
+// Example usage:
+//
+//	spec := hx.NewTriggerSpec("keyup").Changed().Delay(500 * time.Millisecond)
+//	// Renders "keyup changed delay:500ms"
+//
+// In html/template the spec can be rendered as the complete attribute:
+//
+//	<input name="q" {{ .Spec.HTMLAttr }}>
+//	// Renders <input name="q" hx-trigger="keyup changed delay:500ms">
+type TriggerSpec struct {
+	event     string
+	every     *time.Duration
+	filter    string
+	once      bool
+	changed   bool
+	delay     *time.Duration
+	throttle  *time.Duration
+	from      string
+	target    string
+	consume   bool
+	queue     QueueOption
+	root      string
+	threshold *float64
+}
+
+// NewTriggerSpec creates a TriggerSpec for the event.
+//
+// Example usage:
+//
+//	hx.NewTriggerSpec(hx.TriggerRevealed)
+//	// Renders "revealed"
+func NewTriggerSpec(event string) TriggerSpec {
+	return TriggerSpec{event: event}
+}
+
+// Every creates a TriggerSpec that polls at the interval.
+//
+// Example usage:
+//
+//	hx.Every(2 * time.Second)
+//	// Renders "every 2s"
+func Every(interval time.Duration) TriggerSpec {
+	return TriggerSpec{every: &interval}
+}
+
+// Filter sets a JavaScript expression that must be true for the trigger to fire.
+//
+// Example usage:
+//
+//	hx.NewTriggerSpec("click").Filter("ctrlKey")
+//	// Renders "click[ctrlKey]"
+func (s TriggerSpec) Filter(expr string) TriggerSpec {
+	s.filter = expr
+	return s
+}
+
+// Once triggers only once.
+func (s TriggerSpec) Once() TriggerSpec {
+	s.once = true
+	return s
+}
+
+// Changed triggers only when the value of the element has changed.
+func (s TriggerSpec) Changed() TriggerSpec {
+	s.changed = true
+	return s
+}
+
+// Delay waits for the duration before the request is issued; the delay is reset by each new event.
+func (s TriggerSpec) Delay(dur time.Duration) TriggerSpec {
+	s.delay = &dur
+	return s
+}
+
+// Throttle issues the request right away and ignores new events until the duration has passed.
+func (s TriggerSpec) Throttle(dur time.Duration) TriggerSpec {
+	s.throttle = &dur
+	return s
+}
+
+// From listens for the event on another element.
+//
+// The selector may use the extended syntax of htmx, such as "document", "closest form", or "next .item".
+func (s TriggerSpec) From(selector Selector) TriggerSpec {
+	s.from = string(selector)
+	return s
+}
+
+// Target only triggers for events whose target matches the selector.
+func (s TriggerSpec) Target(selector Selector) TriggerSpec {
+	s.target = string(selector)
+	return s
+}
+
+// Consume stops the event from triggering requests on parent elements.
+func (s TriggerSpec) Consume() TriggerSpec {
+	s.consume = true
+	return s
+}
+
+// Queue determines which events are queued while a request is in flight.
+func (s TriggerSpec) Queue(queue QueueOption) TriggerSpec {
+	s.queue = queue
+	return s
+}
+
+// Root sets the root element of the intersection for the intersect event.
+func (s TriggerSpec) Root(selector Selector) TriggerSpec {
+	s.root = string(selector)
+	return s
+}
+
+// Threshold sets the amount of the element, between 0 and 1, that must be visible for the intersect event.
+//
+// Example usage:
+//
+//	hx.NewTriggerSpec(hx.TriggerIntersect).Threshold(0.5)
+//	// Renders "intersect threshold:0.5"
+func (s TriggerSpec) Threshold(threshold float64) TriggerSpec {
+	s.threshold = &threshold
+	return s
+}
+
+// Event returns the name of the event, or an empty string when polling.
+func (s TriggerSpec) Event() string { return s.event }
+
+// Interval returns the polling interval and whether the spec polls.
+func (s TriggerSpec) Interval() (time.Duration, bool) { return durationValue(s.every) }
+
+// String renders the trigger and its modifiers in canonical order.
+func (s TriggerSpec) String() string {
+	var b strings.Builder
+	if s.every != nil {
+		b.WriteString("every " + formatInterval(*s.every))
+		if s.filter != "" {
+			b.WriteString(" ")
+		}
+	} else {
+		b.WriteString(s.event)
+	}
+	if s.filter != "" {
+		b.WriteString("[" + s.filter + "]")
+	}
+
+	if s.once {
+		b.WriteString(" once")
+	}
+	if s.changed {
+		b.WriteString(" changed")
+	}
+	if s.delay != nil {
+		b.WriteString(" delay:" + formatInterval(*s.delay))
+	}
+	if s.throttle != nil {
+		b.WriteString(" throttle:" + formatInterval(*s.throttle))
+	}
+	if s.from != "" {
+		b.WriteString(" from:" + s.from)
+	}
+	if s.target != "" {
+		b.WriteString(" target:" + s.target)
+	}
+	if s.consume {
+		b.WriteString(" consume")
+	}
+	if s.queue != "" {
+		b.WriteString(" queue:" + string(s.queue))
+	}
+	if s.root != "" {
+		b.WriteString(" root:" + s.root)
+	}
+	if s.threshold != nil {
+		b.WriteString(" threshold:" + strconv.FormatFloat(*s.threshold, 'f', -1, 64))
+	}
+
+	return b.String()
+}
+
+// HTMLAttr renders the hx-trigger attribute with the value escaped for html/template.
+func (s TriggerSpec) HTMLAttr() template.HTMLAttr {
+	return TriggerSpecs{s}.HTMLAttr()
+}
+
+// Validate checks the event and modifiers.
+//
+// See TriggerSpecs.Validate for more details.
+func (s TriggerSpec) Validate() error {
+	return TriggerSpecs{s}.Validate()
+}
+
+// TriggerSpecs are the comma separated triggers of an hx-trigger attribute.
+//
+// Example usage:
+//
+//	hx.TriggerSpecs{
+//		hx.NewTriggerSpec(hx.TriggerLoad),
+//		hx.Every(time.Second).Filter("!document.hidden"),
+//	}
+//	// Renders "load, every 1s [!document.hidden]"
+type TriggerSpecs []TriggerSpec
+
+// String renders the triggers separated by commas.
+func (s TriggerSpecs) String() string {
+	parts := make([]string, len(s))
+	for i, spec := range s {
+		parts[i] = spec.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// HTMLAttr renders the hx-trigger attribute with the value escaped for html/template.
+func (s TriggerSpecs) HTMLAttr() template.HTMLAttr {
+	return htmlAttr("hx-trigger", s.String())
+}
+
+// Validate checks the events and modifiers.
+//
+// An error is returned for empty events, filters with unbalanced brackets, selectors that cannot be parsed back,
+// unknown or duplicate modifiers, negative durations, unknown queue options, and root or
+// threshold modifiers on events other than intersect. The threshold must be between 0 and 1,
+// and the durations must be a whole number of milliseconds.
+func (s TriggerSpecs) Validate() error {
+	for _, spec := range s {
+		if err := checkIntervals(spec.every, spec.delay, spec.throttle); err != nil {
+			return fmt.Errorf("invalid trigger %q: %w", s.String(), err)
+		}
+	}
+	if _, err := parseTriggerSpecs(s.String(), true); err != nil {
+		return fmt.Errorf("invalid trigger %q: %w", s.String(), err)
+	}
+
+	return nil
+}
+
+// ParseTriggerSpecs parses the value of an hx-trigger attribute into its triggers.
+//
+// Use Validate on the result to check the modifiers more strictly.
+//
+// Example usage:
+//
+//	specs, err := hx.ParseTriggerSpecs("click[ctrlKey] once, every 2s")
+func ParseTriggerSpecs(value string) (TriggerSpecs, error) {
+	specs, err := parseTriggerSpecs(value, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse hx-trigger: %w", err)
+	}
+
+	return specs, nil
+}
+
+// extendedSelectors are the keywords of the htmx extended selector syntax that are followed by a selector in the from modifier
+var extendedSelectors = map[string]bool{"closest": true, "find": true, "next": true, "previous": true}
+
+// parseTriggerSpecs decodes the comma separated triggers
+//
+// In strict mode duplicate modifiers, negative durations, unknown queue options, and
+// intersection modifiers on other events are rejected.
+func parseTriggerSpecs(value string, strict bool) (TriggerSpecs, error) {
+	var specs TriggerSpecs
+	for _, part := range splitTriggers(value) {
+		spec, err := parseTriggerSpec(part, strict)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// splitTriggers splits the value on the commas that are not part of a filter
+func splitTriggers(value string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, c := range value {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, value[start:])
+}
+
+// filterEnd returns the index of the bracket that closes the filter at the start of the value, or -1
+func filterEnd(value string) int {
+	depth := 0
+	for i, c := range value {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func parseTriggerSpec(value string, strict bool) (TriggerSpec, error) {
+	var s TriggerSpec
+	rest := strings.TrimSpace(value)
+
+	// the event name, or "every" with its interval, with an optional filter
+	event := rest
+	if i := strings.IndexAny(rest, " \t\n["); i >= 0 {
+		event = rest[:i]
+	}
+	rest = strings.TrimSpace(rest[len(event):])
+	if event == "every" {
+		interval := rest
+		if i := strings.IndexAny(rest, " \t\n["); i >= 0 {
+			interval = rest[:i]
+		}
+		dur, err := parseIntervalPtr(interval, strict)
+		if err != nil {
+			return s, fmt.Errorf("every %q: %w", interval, err)
+		}
+		s.every = dur
+		rest = strings.TrimSpace(rest[len(interval):])
+	} else if event == "" {
+		return s, fmt.Errorf("missing event in %q", value)
+	} else {
+		s.event = event
+	}
+	if strings.HasPrefix(rest, "[") {
+		end := filterEnd(rest)
+		if end < 0 {
+			return s, fmt.Errorf("unterminated filter in %q", value)
+		}
+		s.filter = rest[1:end]
+		rest = rest[end+1:]
+	}
+
+	fields := strings.Fields(rest)
+	seen := make(map[string]bool)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		name, arg, _ := strings.Cut(field, ":")
+		if strict && seen[name] {
+			return s, fmt.Errorf("duplicate modifier %q", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "once":
+			s.once = true
+		case "changed":
+			s.changed = true
+		case "consume":
+			s.consume = true
+		case "delay":
+			s.delay, err = parseIntervalPtr(arg, strict)
+		case "throttle":
+			s.throttle, err = parseIntervalPtr(arg, strict)
+		case "from", "target", "root":
+			if name == "from" && extendedSelectors[arg] && i+1 < len(fields) {
+				i++
+				arg += " " + fields[i]
+			}
+			if arg == "" {
+				err = fmt.Errorf("missing selector")
+			}
+			switch name {
+			case "from":
+				s.from = arg
+			case "target":
+				s.target = arg
+			default:
+				s.root = arg
+			}
+		case "queue":
+			s.queue = QueueOption(arg)
+			if strict && s.queue != QueueFirst && s.queue != QueueLast && s.queue != QueueAll && s.queue != QueueNone {
+				err = fmt.Errorf("unknown queue option")
+			}
+		case "threshold":
+			var threshold float64
+			if threshold, err = strconv.ParseFloat(arg, 64); err == nil {
+				s.threshold = &threshold
+				if strict && (threshold < 0 || threshold > 1) {
+					err = fmt.Errorf("threshold must be between 0 and 1")
+				}
+			}
+		default:
+			err = fmt.Errorf("unknown modifier")
+		}
+		if err == nil && strict && (name == "root" || name == "threshold") && s.event != TriggerIntersect {
+			err = fmt.Errorf("only supported by the %s event", TriggerIntersect)
+		}
+		if err != nil {
+			return s, fmt.Errorf("modifier %q: %w", field, err)
+		}
+	}
+
+	return s, nil
+}
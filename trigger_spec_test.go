@@ -0,0 +1,214 @@
+package hx
+
+import (
+	"bytes"
+	"html/template"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriggerSpecs_String(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		specs TriggerSpecs
+		want  string
+	}{
+		"Event only": {
+			specs: TriggerSpecs{NewTriggerSpec(TriggerLoad)},
+			want:  "load",
+		},
+		"Polling": {
+			specs: TriggerSpecs{Every(2 * time.Second)},
+			want:  "every 2s",
+		},
+		"Polling with filter": {
+			specs: TriggerSpecs{Every(time.Second).Filter("!document.hidden")},
+			want:  "every 1s [!document.hidden]",
+		},
+		"Event with filter": {
+			specs: TriggerSpecs{NewTriggerSpec("click").Filter("ctrlKey && shiftKey")},
+			want:  "click[ctrlKey && shiftKey]",
+		},
+		"Intersect": {
+			specs: TriggerSpecs{NewTriggerSpec(TriggerIntersect).Root("#list").Threshold(0.5).Once()},
+			want:  "intersect once root:#list threshold:0.5",
+		},
+		"Canonical order": {
+			specs: TriggerSpecs{NewTriggerSpec("keyup").
+				Queue(QueueLast).
+				Consume().
+				Target("#input").
+				From("closest form").
+				Throttle(time.Second).
+				Delay(500 * time.Millisecond).
+				Changed().
+				Once(),
+			},
+			want: "keyup once changed delay:500ms throttle:1s from:closest form target:#input consume queue:last",
+		},
+		"Modifiers replace each other": {
+			specs: TriggerSpecs{NewTriggerSpec("input").Delay(time.Second).Delay(2 * time.Second).From("#a").From("document")},
+			want:  "input delay:2s from:document",
+		},
+		"Filter with a bracketed selector": {
+			specs: TriggerSpecs{NewTriggerSpec("click").Filter("list[0]").From("input[name=q]")},
+			want:  "click[list[0]] from:input[name=q]",
+		},
+		"Multiple triggers": {
+			specs: TriggerSpecs{
+				NewTriggerSpec(TriggerRevealed),
+				NewTriggerSpec("item-added").From("body"),
+				Every(time.Minute).Filter("a, b"),
+			},
+			want: "revealed, item-added from:body, every 60s [a, b]",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.specs.String())
+			assert.NoError(t, tt.specs.Validate())
+
+			parsed, err := ParseTriggerSpecs(tt.specs.String())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.specs, parsed)
+		})
+	}
+}
+
+func TestTriggerSpecs_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		specs   TriggerSpecs
+		wantErr bool
+	}{
+		"Valid": {
+			specs: TriggerSpecs{NewTriggerSpec("click").Queue(QueueNone)},
+		},
+		"Empty": {
+			specs:   TriggerSpecs{},
+			wantErr: true,
+		},
+		"Missing event": {
+			specs:   TriggerSpecs{NewTriggerSpec("")},
+			wantErr: true,
+		},
+		"Negative delay": {
+			specs:   TriggerSpecs{NewTriggerSpec("click").Delay(-time.Second)},
+			wantErr: true,
+		},
+		"Negative interval": {
+			specs:   TriggerSpecs{Every(-time.Second)},
+			wantErr: true,
+		},
+		"Unknown queue option": {
+			specs:   TriggerSpecs{NewTriggerSpec("click").Queue("later")},
+			wantErr: true,
+		},
+		"Threshold out of range": {
+			specs:   TriggerSpecs{NewTriggerSpec(TriggerIntersect).Threshold(1.5)},
+			wantErr: true,
+		},
+		"Root without intersect": {
+			specs:   TriggerSpecs{NewTriggerSpec("click").Root("#list")},
+			wantErr: true,
+		},
+		"Unbalanced filter": {
+			specs:   TriggerSpecs{NewTriggerSpec("click").Filter("a]").Once()},
+			wantErr: true,
+		},
+		"Unterminated filter": {
+			specs:   TriggerSpecs{NewTriggerSpec("click").Filter("a[0")},
+			wantErr: true,
+		},
+		"Selector with spaces": {
+			specs:   TriggerSpecs{NewTriggerSpec("click").Target("#list .item")},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.specs.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseTriggerSpecs(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value   string
+		want    TriggerSpecs
+		wantErr bool
+	}{
+		"Millisecond values": {
+			value: "every 500 , keyup delay:250",
+			want: TriggerSpecs{
+				Every(500 * time.Millisecond),
+				NewTriggerSpec("keyup").Delay(250 * time.Millisecond),
+			},
+		},
+		"Extended from selector": {
+			value: "change from:next .item target:input",
+			want:  TriggerSpecs{NewTriggerSpec("change").From("next .item").Target("input")},
+		},
+		"Filter with brackets": {
+			value: "keyup[key=='Enter' && list[0]]",
+			want:  TriggerSpecs{NewTriggerSpec("keyup").Filter("key=='Enter' && list[0]")},
+		},
+		"Filter followed by a bracketed from selector": {
+			value: "click[a] from:input[name=q]",
+			want:  TriggerSpecs{NewTriggerSpec("click").Filter("a").From("input[name=q]")},
+		},
+		"Filter followed by a bracketed target selector": {
+			value: "click[ctrlKey] target:button[type=submit] once",
+			want:  TriggerSpecs{NewTriggerSpec("click").Filter("ctrlKey").Target("button[type=submit]").Once()},
+		},
+		"Unbalanced filter": {
+			value:   "click[a]] once",
+			wantErr: true,
+		},
+		"Unknown modifier": {
+			value:   "click later",
+			wantErr: true,
+		},
+		"Unterminated filter": {
+			value:   "click[ctrlKey",
+			wantErr: true,
+		},
+		"Missing interval": {
+			value:   "every",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseTriggerSpecs(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTriggerSpec_HTMLAttr(t *testing.T) {
+	t.Parallel()
+
+	tmpl := template.Must(template.New("").Parse(`<input {{ .HTMLAttr }} data-trigger="{{ . }}">`))
+	var b bytes.Buffer
+	err := tmpl.Execute(&b, NewTriggerSpec("keyup").Filter(`key=="Enter"`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, `<input hx-trigger="keyup[key==&#34;Enter&#34;]" data-trigger="keyup[key==&#34;Enter&#34;]">`, b.String())
+}
@@ -1,14 +1,19 @@
 package hx
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 )
 
 // internal types related to Location
 
-type property interface {
+// LocationProperty is an interface that can be used to set the properties of the HX-Location header
+type LocationProperty interface {
 	apply(*location)
 }
 
@@ -19,7 +24,7 @@ func (f propertyFunc) apply(o *location) { f(o) }
 type location struct {
 	Path    string            `json:"path"`
 	Source  string            `json:"source,omitempty"`
-	Event   string            `json:"event,omitempty"`
+	Event   any               `json:"event,omitempty"`
 	Handler string            `json:"handler,omitempty"`
 	Target  string            `json:"target,omitempty"`
 	Swap    string            `json:"swap,omitempty"`
@@ -28,23 +33,18 @@ type location struct {
 	Select  string            `json:"select,omitempty"`
 }
 
-// TODO Can you return a complete event in the HX-Location event property? If so uncomment this
-// func (e event) apply(o *location) {
-// 	data, err := json.Marshal(e)
-// 	if err != nil {
-// 		panic(fmt.Errorf("unable to marshal event: %w", err))
-// 	}
-// 	o.Event = string(data)
-// }
-
 // internal types related to the response
 
 // HtmxResponse is a struct that contains the headers and status code to be returned to the client
 //
 // This is helpful for using HTMX with a framework that doesn't implement the stdlib http.ResponseWriter
 type HtmxResponse struct {
-	headers map[string]string
-	status  int
+	headers  map[string]string
+	triggers map[string][]ParsedEvent
+	targeted map[string]map[string]bool
+	status   int
+	strict   bool
+	version  Version
 }
 
 func (r HtmxResponse) Headers() map[string]string { return r.headers }
@@ -55,30 +55,188 @@ func (r HtmxResponse) StatusCode() int {
 	return r.status
 }
 
+func (r *HtmxResponse) setHeader(header, value string) error {
+	r.headers[header] = value
+	return nil
+}
+
+func (r *HtmxResponse) setStatus(status int) error {
+	r.status = status
+	return nil
+}
+
 // ResponseOption is an interface that can be used to set the headers and status code of the response
+//
+// Options report problems, such as event data that cannot be marshaled, by returning an error.
+// The errors of all options are collected by BuildResponse.
 type ResponseOption interface {
-	apply(*HtmxResponse)
+	apply(*HtmxResponse) error
 }
 
-type responseOptionFunc func(*HtmxResponse)
+type responseOptionFunc func(*HtmxResponse) error
 
-func (f responseOptionFunc) apply(o *HtmxResponse) { f(o) }
+func (f responseOptionFunc) apply(o *HtmxResponse) error { return f(o) }
 
-// internal types related to triggering events
+// types related to triggering events
 
-type event func() map[string]any
+// TriggerEvent is an event that can be triggered on the client.
+//
+// Use Event to create events to pass to Trigger, TriggerAfterSettle, and TriggerAfterSwap.
+// The same events can also be sent to the client in other ways, for example with the hxsse package.
+type TriggerEvent func() map[string]any
+
+// MarshalJSON returns the event as a JSON object of the event name to its data.
+func (e TriggerEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e())
+}
+
+// apply sets the 'event' property of the HX-Location header to the complete event object
+func (e TriggerEvent) apply(o *location) { o.Event = e }
+
+// targetedEvent is the data of an event that is triggered on a specific element
+type targetedEvent struct {
+	Target string `json:"target"`
+	Value  any    `json:"value,omitempty"`
+}
 
-func triggeredEvents(events []event) []byte {
-	m := make(map[string]any)
+// triggerHeaders are the headers that accumulate events
+var triggerHeaders = []string{HxTrigger, HxTriggerAfterSettle, HxTriggerAfterSwap}
+
+// addEvents marshals the data of each event on its own so errors can name the event
+func (r *HtmxResponse) addEvents(header string, events []TriggerEvent) error {
+	var added []ParsedEvent
+	var errs []error
 	for _, event := range events {
-		for k, v := range event() {
-			m[k] = v
+		values := event()
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			data, err := json.Marshal(values[name])
+			if err != nil {
+				errs = append(errs, fmt.Errorf("event %q: %w", name, err))
+				continue
+			}
+			if bytes.Equal(data, []byte("null")) {
+				data = nil
+			}
+			if _, ok := values[name].(targetedEvent); ok {
+				if r.targeted == nil {
+					r.targeted = make(map[string]map[string]bool)
+				}
+				if r.targeted[header] == nil {
+					r.targeted[header] = make(map[string]bool)
+				}
+				r.targeted[header][name] = true
+			}
+			added = append(added, ParsedEvent{Name: name, Data: data})
 		}
 	}
-	data, err := json.Marshal(m)
-	if err != nil {
-		panic(fmt.Errorf("unable to marshal all events: %w", err))
+	if len(errs) > 0 {
+		return fmt.Errorf("%s: %w", header, errors.Join(errs...))
+	}
+
+	if r.triggers == nil {
+		r.triggers = make(map[string][]ParsedEvent)
+	}
+	r.triggers[header] = append(r.triggers[header], added...)
+
+	return r.setTriggerHeader(header)
+}
+
+// setTriggerHeader sets the header to the accumulated events in the order they were added
+//
+// The comma separated form is used when none of the events have any data, and the header
+// is left out when there are no events.
+func (r *HtmxResponse) setTriggerHeader(header string) error {
+	events := r.triggers[header]
+	if len(events) == 0 {
+		delete(r.headers, header)
+		return nil
+	}
+	seen := make(map[string]bool, len(events))
+	simple := true
+	for _, event := range events {
+		if seen[event.Name] {
+			return fmt.Errorf("%s: duplicate event %q", header, event.Name)
+		}
+		seen[event.Name] = true
+		if event.Data != nil || strings.ContainsAny(event.Name, ", \t\"{") {
+			simple = false
+		}
+	}
+
+	var b strings.Builder
+	if simple {
+		for i, event := range events {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(event.Name)
+		}
+		return r.setHeader(header, b.String())
+	}
+
+	b.WriteString("{")
+	for i, event := range events {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		name, err := json.Marshal(event.Name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", header, err)
+		}
+		b.Write(name)
+		b.WriteString(":")
+		if event.Data == nil {
+			b.WriteString("null")
+		} else {
+			b.Write(event.Data)
+		}
+	}
+	b.WriteString("}")
+
+	return r.setHeader(header, b.String())
+}
+
+// MergeTriggers merges the trigger headers that have already been set on the response with the
+// events of this HtmxResponse.
+//
+// The existing function returns the current value of a response header. The events that were
+// already set come first, followed by the events of this HtmxResponse. Using the same event
+// name in both is an error.
+//
+// Response calls MergeTriggers for you. It can be used to merge events when implementing a
+// response helper for your own HTTP library.
+//
+// Example usage:
+//
+//	o, err := hx.BuildResponse(options...)
+//	// handle err
+//	err = o.MergeTriggers(w.Header().Get)
+//	// handle err
+func (r *HtmxResponse) MergeTriggers(existing func(header string) string) error {
+	var errs []error
+	for _, header := range triggerHeaders {
+		if len(r.triggers[header]) == 0 {
+			continue
+		}
+		value := strings.TrimSpace(existing(header))
+		if value == "" {
+			continue
+		}
+		events, err := parseTriggerValue(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to merge %s header: %w", header, err))
+			continue
+		}
+		r.triggers[header] = append(events, r.triggers[header]...)
+		if err = r.setTriggerHeader(header); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	return data
+	return errors.Join(errs...)
 }
@@ -0,0 +1,68 @@
+package hx
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type varyContextKey struct{}
+
+// VaryMiddleware adds the HTMX request headers that a handler reads to the Vary response header.
+//
+// Handlers commonly return a partial for HTMX requests and a full page otherwise. Without
+// a matching Vary header, caches such as CDNs and browsers can serve a cached partial as a
+// full page, or the other way around.
+//
+// Each request helper that is called while handling the request, such as IsHtmx, IsBoosted,
+// or GetTarget, adds its header to the Vary header of the response. Headers that are read
+// after the response headers have been written are not added. ParseRequest, and so
+// RequestMiddleware when it is wrapped by VaryMiddleware, only adds HX-Request, HX-Boosted,
+// HX-History-Restore-Request, and HX-Target, on every request it parses.
+//
+// Example usage:
+//
+//	mux := http.NewServeMux()
+//	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+//		if hx.IsHtmx(r) {
+//			// render a partial
+//		}
+//	})
+//	http.ListenAndServe(":8080", hx.VaryMiddleware(mux))
+//	// Responses will include the header "Vary: Hx-Request"
+func VaryMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), varyContextKey{}, w.Header())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// AddVary adds the header names to the Vary header, skipping any names that are already present.
+//
+// It can be used to implement VaryMiddleware for your own HTTP library.
+func AddVary(h http.Header, headers ...string) {
+	for _, header := range headers {
+		if !hasVary(h, header) {
+			h.Add("Vary", header)
+		}
+	}
+}
+
+func hasVary(h http.Header, header string) bool {
+	for _, value := range h.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "*" || strings.EqualFold(name, header) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// varyOn records the header in the Vary header of the response when VaryMiddleware is in use
+func varyOn(r *http.Request, header string) {
+	if h, ok := r.Context().Value(varyContextKey{}).(http.Header); ok {
+		AddVary(h, header)
+	}
+}
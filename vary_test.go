@@ -0,0 +1,94 @@
+package hx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// parsedVaryHeaders are the headers added to Vary by ParseRequest in the order they are read
+var parsedVaryHeaders = []string{
+	HxBoosted,
+	HxHistoryRestoreRequest,
+	HxRequest,
+	HxTarget,
+}
+
+func TestVaryMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		handler http.HandlerFunc
+		header  http.Header
+		want    []string
+	}{
+		"No headers read": {
+			handler: func(w http.ResponseWriter, r *http.Request) {},
+			want:    nil,
+		},
+		"IsHtmx": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_ = IsHtmx(r)
+			},
+			want: []string{HxRequest},
+		},
+		"Read every header once": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_ = IsRequest(r)
+				_ = IsBoosted(r)
+				_ = GetTarget(r)
+				_ = IsHtmx(r)
+				_ = GetTarget(r)
+			},
+			want: []string{HxRequest, HxBoosted, HxTarget},
+		},
+		"Keeps existing values": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Vary", "Accept-Encoding, hx-request")
+				_ = IsHtmx(r)
+				_ = GetTrigger(r)
+			},
+			want: []string{"Accept-Encoding, hx-request", HxTrigger},
+		},
+		"Skips everything when varying on all": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Vary", "*")
+				_ = IsHtmx(r)
+			},
+			want: []string{"*"},
+		},
+		"ParseRequest": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_ = ParseRequest(r)
+			},
+			want: parsedVaryHeaders,
+		},
+		"RequestMiddleware": {
+			handler: RequestMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = FromContext(r.Context())
+			})).ServeHTTP,
+			want: parsedVaryHeaders,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			wr := httptest.NewRecorder()
+			VaryMiddleware(tt.handler).ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tt.want, wr.Header().Values("Vary"))
+		})
+	}
+}
+
+func TestVaryMiddleware_NotUsed(t *testing.T) {
+	t.Parallel()
+
+	wr := httptest.NewRecorder()
+	http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = IsHtmx(r)
+	}).ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Empty(t, wr.Header().Values("Vary"))
+}
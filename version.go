@@ -0,0 +1,170 @@
+package hx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Version is the major version of htmx running on the client.
+//
+// When a Version is known, BuildResponse checks that the headers will be read by that
+// version as intended and returns an error for those that will not be:
+//
+//   - Version1 rejects the options that were added in htmx 2: the textContent swap style,
+//     the select property of the HX-Location header, and targeted events.
+//   - Version2 rejects events whose data has a target property without being created with
+//     TriggerEvent.Target, because htmx 2 would trigger them on the selected element.
+//
+// The version is taken from DefaultVersion, and can be set for a single response by
+// using the Version as an option:
+//
+//	hx.Response(w, hx.Version1, hx.Reswap(hx.SwapTextContent))
+//	// returns an error: Hx-Reswap: swap style "textContent" requires htmx 2
+type Version int
+
+// Version constants
+const (
+	// VersionUnspecified skips the version checks
+	VersionUnspecified Version = iota
+	// Version1 is htmx 1.x
+	Version1
+	// Version2 is htmx 2.x
+	Version2
+)
+
+// DefaultVersion is the htmx version used by responses that do not set a Version.
+//
+// Set it once during startup when all clients use the same version of htmx.
+var DefaultVersion = VersionUnspecified
+
+// VersionHeader is the request header read by GetVersion.
+//
+// htmx does not send its version, so the client needs to add the header to each request:
+//
+//	document.body.addEventListener("htmx:configRequest", (e) => {
+//		e.detail.headers["HX-Version"] = htmx.version;
+//	});
+var VersionHeader = "Hx-Version"
+
+func (v Version) apply(o *HtmxResponse) error {
+	o.version = v
+	return nil
+}
+
+// String returns the version as "1" or "2", or "unspecified".
+func (v Version) String() string {
+	if v == VersionUnspecified {
+		return "unspecified"
+	}
+	return strconv.Itoa(int(v))
+}
+
+// ParseVersion parses a version string such as "1.9.12", "v2.0.0", or "2" into a Version.
+//
+// Unknown or invalid versions return VersionUnspecified.
+func ParseVersion(value string) Version {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "v")
+	major, _, _ := strings.Cut(value, ".")
+	switch major {
+	case "1":
+		return Version1
+	case "2":
+		return Version2
+	default:
+		return VersionUnspecified
+	}
+}
+
+// GetVersion detects the htmx version from the VersionHeader of an HTTP request.
+//
+// It returns VersionUnspecified if the header is not present.
+func GetVersion(r *http.Request) Version {
+	return readHeaders(r).GetVersion()
+}
+
+// validateVersion checks that the headers are supported by the htmx version
+func (r *HtmxResponse) validateVersion() error {
+	switch r.version {
+	case Version1:
+		return r.validateVersion1()
+	case Version2:
+		return r.validateVersion2()
+	default:
+		return nil
+	}
+}
+
+// validateVersion1 rejects the options that were added in htmx 2
+func (r *HtmxResponse) validateVersion1() error {
+	var errs []error
+	for _, header := range triggerHeaders {
+		for _, event := range r.triggers[header] {
+			if r.targeted[header][event.Name] {
+				errs = append(errs, fmt.Errorf("%s: event %q: targeted events require htmx 2", header, event.Name))
+			}
+		}
+	}
+	if value, ok := r.headers[HxReswap]; ok {
+		if err := requireSwapStyle(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", HxReswap, err))
+		}
+	}
+	if value, ok := r.headers[HxLocation]; ok {
+		if loc, err := ParseLocation(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", HxLocation, err))
+		} else {
+			if loc.Swap != "" {
+				if err = requireSwapStyle(loc.Swap); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", HxLocation, err))
+				}
+			}
+			if loc.Select != "" {
+				errs = append(errs, fmt.Errorf("%s: the select property requires htmx 2", HxLocation))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateVersion2 rejects the events that htmx 2 would trigger on another element
+//
+// htmx 2 reads the target property of an event with object data as the element to trigger
+// the event on, so the data of the events that are not targeted must not have one.
+func (r *HtmxResponse) validateVersion2() error {
+	var errs []error
+	for _, header := range triggerHeaders {
+		for _, event := range r.triggers[header] {
+			if r.targeted[header][event.Name] || !bytes.HasPrefix(event.Data, []byte("{")) {
+				continue
+			}
+			var data map[string]json.RawMessage
+			if err := json.Unmarshal(event.Data, &data); err != nil {
+				continue
+			}
+			if _, exists := data["target"]; exists {
+				errs = append(errs, fmt.Errorf("%s: event %q: htmx 2 reads the target property of the data as the element to trigger the event on; use TriggerEvent.Target", header, event.Name))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// requireSwapStyle rejects the swap styles that were added in htmx 2
+func requireSwapStyle(value string) error {
+	spec, err := parseReswap(value, false)
+	if err != nil {
+		return err
+	}
+	if spec.style == SwapTextContent {
+		return fmt.Errorf("swap style %q requires htmx 2", spec.style)
+	}
+
+	return nil
+}
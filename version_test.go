@@ -0,0 +1,106 @@
+package hx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value string
+		want  Version
+	}{
+		"Empty":         {value: "", want: VersionUnspecified},
+		"Major only":    {value: "2", want: Version2},
+		"Full version":  {value: "1.9.12", want: Version1},
+		"With v prefix": {value: "v2.0.0", want: Version2},
+		"Unknown major": {value: "3.0.0", want: VersionUnspecified},
+		"Invalid":       {value: "latest", want: VersionUnspecified},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ParseVersion(tc.value))
+		})
+	}
+}
+
+func TestGetVersion(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	assert.Equal(t, VersionUnspecified, GetVersion(r))
+
+	r.Header.Set(VersionHeader, "1.9.10")
+	assert.Equal(t, Version1, GetVersion(r))
+}
+
+func TestBuildResponse_Version(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		options []ResponseOption
+		wantErr string
+	}{
+		"Unspecified allows everything": {
+			options: []ResponseOption{SwapTextContent, Location("/foo", Select("#main"))},
+		},
+		"Version 2 allows textContent": {
+			options: []ResponseOption{Version2, SwapTextContent},
+		},
+		"Version 1 allows other styles": {
+			options: []ResponseOption{Version1, SwapOuterHtml.Transition(), Location("/foo", Swap(SwapInnerHtml))},
+		},
+		"Version 1 rejects textContent": {
+			options: []ResponseOption{Version1, SwapTextContent.Settle(0)},
+			wantErr: `Hx-Reswap: swap style "textContent" requires htmx 2`,
+		},
+		"Version 1 rejects textContent in location": {
+			options: []ResponseOption{Version1, Location("/foo", Swap(SwapTextContent))},
+			wantErr: `Hx-Location: swap style "textContent" requires htmx 2`,
+		},
+		"Version 1 rejects select in location": {
+			options: []ResponseOption{Location("/foo", Select("#main")), Version1},
+			wantErr: `Hx-Location: the select property requires htmx 2`,
+		},
+		"Version 1 rejects targeted events": {
+			options: []ResponseOption{Version1, Trigger(Event("a"), EventOn("#b", "b")), TriggerAfterSwap(Event("c", 1).Target("#c"))},
+			wantErr: "HX-Trigger: event \"b\": targeted events require htmx 2\n" +
+				"Hx-Trigger-After-Swap: event \"c\": targeted events require htmx 2",
+		},
+		"Version 1 allows data with a target": {
+			options: []ResponseOption{Version1, Trigger(Event("a", map[string]string{"target": "draft"}))},
+		},
+		"Version 2 allows targeted events": {
+			options: []ResponseOption{Version2, Trigger(EventOn("#b", "b", 1), Event("c", map[string]int{"id": 1}))},
+		},
+		"Version 2 rejects data with a target": {
+			options: []ResponseOption{Version2, TriggerAfterSettle(Event("a", map[string]string{"target": "draft"}))},
+			wantErr: `Hx-Trigger-After-Settle: event "a": htmx 2 reads the target property of the data as the element to trigger the event on; use TriggerEvent.Target`,
+		},
+		"Unspecified allows data with a target": {
+			options: []ResponseOption{Trigger(Event("a", map[string]string{"target": "draft"}))},
+		},
+		"Version 1 keeps all errors": {
+			options: []ResponseOption{Version1, SwapTextContent, responseOptionFunc(func(o *HtmxResponse) error {
+				return o.setHeader(HxLocation, "{bad")
+			})},
+			wantErr: "Hx-Reswap: swap style \"textContent\" requires htmx 2\n" +
+				"Hx-Location: unable to parse HX-Location header: invalid character 'b' looking for beginning of object key string",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := BuildResponse(tc.options...)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package hx
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// ErrHeadersWritten is returned by Add when the headers of a Writer have already been written.
+var ErrHeadersWritten = errors.New("response headers have already been written")
+
+// Writer is a http.ResponseWriter that collects HTMX options until the response is written.
+//
+// Response sets the headers right away and may call WriteHeader, so options from a layer
+// that runs later are lost. The options passed to Add are kept by the Writer instead and are
+// applied on the first call to Write, WriteHeader, or Flush. Trigger events from every layer
+// are merged together, see Trigger for the rules.
+//
+// A status set with the Status option is used when the response is written implicitly by
+// Write or Flush. A status code passed to WriteHeader takes precedence.
+//
+// When the collected options cannot be applied, for example because two layers triggered
+// the same event, none of the HTMX headers are set and the error handler writes the response
+// instead. The default error handler responds with 500 Internal Server Error; use
+// WriterErrorHandler to change it. Later writes to the body are discarded and return the error.
+//
+// Use NewWriter or WriterMiddleware to create a Writer.
+type Writer struct {
+	http.ResponseWriter
+	options      []ResponseOption
+	wroteHeader  bool
+	err          error
+	errorHandler func(w http.ResponseWriter, err error)
+}
+
+// WriterOption configures a Writer.
+type WriterOption func(*Writer)
+
+// WriterErrorHandler sets the function that writes the response when the collected options cannot be applied.
+//
+// The handler is called with the wrapped http.ResponseWriter, before any status code has been written.
+//
+// Example usage:
+//
+//	hx.WriterMiddleware(mux, hx.WriterErrorHandler(func(w http.ResponseWriter, err error) {
+//		slog.Error("htmx response", "error", err)
+//		http.Error(w, "Something went wrong", http.StatusInternalServerError)
+//	}))
+func WriterErrorHandler(handler func(w http.ResponseWriter, err error)) WriterOption {
+	return func(w *Writer) { w.errorHandler = handler }
+}
+
+// NewWriter wraps the http.ResponseWriter in a Writer.
+func NewWriter(w http.ResponseWriter, options ...WriterOption) *Writer {
+	hw := &Writer{ResponseWriter: w, errorHandler: internalServerError}
+	for _, option := range options {
+		option(hw)
+	}
+	return hw
+}
+
+// WriterMiddleware wraps the http.ResponseWriter in a Writer so any layer can use Add.
+//
+// The collected options are applied after the next handler returns if it did not write a response.
+// See Writer for how errors from applying the options are handled.
+//
+// Example usage:
+//
+//	mux := http.NewServeMux()
+//	mux.HandleFunc("/", MyHandler)
+//	http.ListenAndServe(":8080", hx.WriterMiddleware(mux))
+func WriterMiddleware(next http.Handler, options ...WriterOption) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hw := NewWriter(w, options...)
+		next.ServeHTTP(hw, r)
+		hw.writeHeader(0)
+	})
+}
+
+// Add collects HTMX options on the Writer found in the http.ResponseWriter.
+//
+// The Writer is found by following the Unwrap methods of any wrapping writers. When there is
+// no Writer, the options are applied immediately using Response.
+//
+// Example usage:
+//
+//	func AuthMiddleware(next http.Handler) http.Handler {
+//		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//			_ = hx.Add(w, hx.Trigger(hx.Event("session-refreshed")))
+//			next.ServeHTTP(w, r)
+//		})
+//	}
+//
+//	func MyHandler(w http.ResponseWriter, r *http.Request) {
+//		err := hx.Add(w, hx.Trigger(hx.Event("myEvent")))
+//		// handle err
+//		w.Write([]byte("saved"))
+//		// HX-Trigger: session-refreshed, myEvent
+//	}
+func Add(w http.ResponseWriter, options ...ResponseOption) error {
+	hw, ok := findWriter(w)
+	if !ok {
+		return Response(w, options...)
+	}
+
+	return hw.Add(options...)
+}
+
+// Add collects the HTMX options until the response is written.
+//
+// The options are checked when they are added so any errors are returned right away.
+func (w *Writer) Add(options ...ResponseOption) error {
+	if w.wroteHeader {
+		return ErrHeadersWritten
+	}
+	if _, err := BuildResponse(options...); err != nil {
+		return err
+	}
+	w.options = append(w.options, options...)
+
+	return nil
+}
+
+// Err returns the error, if any, from applying the collected options when the response was written.
+func (w *Writer) Err() error { return w.err }
+
+// WriteHeader applies the collected options and writes the status code.
+func (w *Writer) WriteHeader(statusCode int) {
+	w.writeHeader(statusCode)
+}
+
+// Write applies the collected options before writing the first bytes of the body.
+//
+// The error from applying the options is returned and the body is not written.
+func (w *Writer) Write(b []byte) (int, error) {
+	w.writeHeader(0)
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush applies the collected options and flushes the wrapped http.ResponseWriter if it supports it.
+func (w *Writer) Flush() {
+	w.writeHeader(0)
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack lets the caller take over the connection of the wrapped http.ResponseWriter.
+//
+// Once the connection has been hijacked the collected options are no longer applied.
+func (w *Writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if err == nil {
+		w.wroteHeader = true
+	}
+	return conn, rw, err
+}
+
+// Unwrap returns the wrapped http.ResponseWriter for use with http.ResponseController.
+func (w *Writer) Unwrap() http.ResponseWriter { return w.ResponseWriter }
+
+// writeHeader applies the collected options once; a status code of 0 uses the status option if set
+func (w *Writer) writeHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	o, err := BuildResponse(w.options...)
+	if err == nil {
+		err = o.MergeTriggers(w.ResponseWriter.Header().Get)
+	}
+	if err != nil {
+		// the status of the handler is dropped so the client never gets a partial set of headers
+		w.err = err
+		w.errorHandler(w.ResponseWriter, err)
+		return
+	}
+
+	for k, v := range o.headers {
+		w.ResponseWriter.Header().Set(k, v)
+	}
+	if statusCode == 0 {
+		statusCode = o.status
+	}
+	if statusCode != 0 {
+		w.ResponseWriter.WriteHeader(statusCode)
+	}
+}
+
+// internalServerError is the default error handler of a Writer
+func internalServerError(w http.ResponseWriter, _ error) {
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+// findWriter follows the Unwrap methods of wrapping writers to find a Writer
+func findWriter(w http.ResponseWriter) (*Writer, bool) {
+	for {
+		switch rw := w.(type) {
+		case *Writer:
+			return rw, true
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = rw.Unwrap()
+		default:
+			return nil, false
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package hx
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		handler     func(w http.ResponseWriter) error
+		wantHeaders map[string]string
+		wantStatus  int
+		wantBody    string
+	}{
+		"Apply options on write": {
+			handler: func(w http.ResponseWriter) error {
+				if err := Add(w, Retarget("#foo")); err != nil {
+					return err
+				}
+				_, err := w.Write([]byte("body"))
+				return err
+			},
+			wantHeaders: map[string]string{HxRetarget: "#foo"},
+			wantStatus:  http.StatusOK,
+			wantBody:    "body",
+		},
+		"Merge triggers from several layers": {
+			handler: func(w http.ResponseWriter) error {
+				if err := Add(w, Trigger(Event("a"))); err != nil {
+					return err
+				}
+				if err := Add(w, Trigger(Event("b", 1))); err != nil {
+					return err
+				}
+				w.WriteHeader(http.StatusCreated)
+				return nil
+			},
+			wantHeaders: map[string]string{HxTrigger: `{"a":null,"b":1}`},
+			wantStatus:  http.StatusCreated,
+		},
+		"Merge with headers set by Response": {
+			handler: func(w http.ResponseWriter) error {
+				if err := Response(w, Trigger(Event("a"))); err != nil {
+					return err
+				}
+				return Add(w, Trigger(Event("b")))
+			},
+			wantHeaders: map[string]string{HxTrigger: "a, b"},
+			wantStatus:  http.StatusOK,
+		},
+		"Status option on implicit write": {
+			handler: func(w http.ResponseWriter) error {
+				if err := Add(w, StatusStopPolling); err != nil {
+					return err
+				}
+				_, err := w.Write([]byte("done"))
+				return err
+			},
+			wantStatus: int(StatusStopPolling),
+			wantBody:   "done",
+		},
+		"WriteHeader wins over status option": {
+			handler: func(w http.ResponseWriter) error {
+				if err := Add(w, StatusStopPolling); err != nil {
+					return err
+				}
+				w.WriteHeader(http.StatusAccepted)
+				return nil
+			},
+			wantStatus: http.StatusAccepted,
+		},
+		"Apply options on flush": {
+			handler: func(w http.ResponseWriter) error {
+				if err := Add(w, PushUrl("/foo")); err != nil {
+					return err
+				}
+				return http.NewResponseController(w).Flush()
+			},
+			wantHeaders: map[string]string{HxPushUrl: "/foo"},
+			wantStatus:  http.StatusOK,
+		},
+		"Apply options when the handler does not write": {
+			handler: func(w http.ResponseWriter) error {
+				return Add(w, Refresh())
+			},
+			wantHeaders: map[string]string{HxRefresh: "true"},
+			wantStatus:  http.StatusOK,
+		},
+		"Find writer through a wrapping writer": {
+			handler: func(w http.ResponseWriter) error {
+				return Add(wrappingWriter{w}, Reselect("#bar"))
+			},
+			wantHeaders: map[string]string{HxReselect: "#bar"},
+			wantStatus:  http.StatusOK,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			wr := httptest.NewRecorder()
+			handler := WriterMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.NoError(t, tc.handler(w))
+			}))
+
+			handler.ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			for k, v := range tc.wantHeaders {
+				assert.Equal(t, v, wr.Header().Get(k))
+			}
+			assert.Equal(t, tc.wantStatus, wr.Code)
+			assert.Equal(t, tc.wantBody, wr.Body.String())
+		})
+	}
+}
+
+func TestWriter_Add(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Without a writer", func(t *testing.T) {
+		wr := httptest.NewRecorder()
+		assert.NoError(t, Add(wr, Retarget("#foo")))
+		assert.Equal(t, "#foo", wr.Header().Get(HxRetarget))
+	})
+
+	t.Run("After the headers are written", func(t *testing.T) {
+		w := NewWriter(httptest.NewRecorder())
+		w.WriteHeader(http.StatusOK)
+		assert.ErrorIs(t, Add(w, Retarget("#foo")), ErrHeadersWritten)
+	})
+
+	t.Run("Bad option", func(t *testing.T) {
+		w := NewWriter(httptest.NewRecorder())
+		assert.Error(t, Add(w, Trigger(Event("x", make(chan int)))))
+		assert.Empty(t, w.options)
+	})
+
+	t.Run("Duplicate event error on write", func(t *testing.T) {
+		wr := httptest.NewRecorder()
+		w := NewWriter(wr)
+		assert.NoError(t, Add(w, Trigger(Event("a"))))
+		assert.NoError(t, Add(w, Trigger(Event("a"))))
+
+		n, err := w.Write([]byte("body"))
+		assert.Error(t, err)
+		assert.Equal(t, 0, n)
+		assert.Equal(t, err, w.Err())
+		assert.Equal(t, http.StatusInternalServerError, wr.Code)
+		assert.Empty(t, wr.Header().Get(HxTrigger))
+		assert.NotContains(t, wr.Body.String(), "body")
+	})
+}
+
+func TestWriterMiddleware_Error(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		handler    func(w http.ResponseWriter)
+		options    []WriterOption
+		wantStatus int
+		wantBody   string
+	}{
+		"Error on write": {
+			handler: func(w http.ResponseWriter) {
+				_, _ = w.Write([]byte("body"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "Internal Server Error\n",
+		},
+		"Error on WriteHeader": {
+			handler: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusCreated)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "Internal Server Error\n",
+		},
+		"Error when the handler does not write": {
+			handler:    func(w http.ResponseWriter) {},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "Internal Server Error\n",
+		},
+		"Custom error handler": {
+			handler: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte("body"))
+			},
+			options: []WriterOption{WriterErrorHandler(func(w http.ResponseWriter, err error) {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(err.Error()))
+			})},
+			wantStatus: http.StatusConflict,
+			wantBody:   `HX-Trigger: duplicate event "a"`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			wr := httptest.NewRecorder()
+			handler := WriterMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.NoError(t, Add(w, Retarget("#foo"), Trigger(Event("a"))))
+				assert.NoError(t, Add(w, Trigger(Event("a"))))
+				tc.handler(w)
+			}), tc.options...)
+
+			handler.ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tc.wantStatus, wr.Code)
+			assert.Equal(t, tc.wantBody, wr.Body.String())
+			assert.Empty(t, wr.Header().Get(HxRetarget))
+			assert.Empty(t, wr.Header().Get(HxTrigger))
+		})
+	}
+}
+
+func TestWriter_Hijack(t *testing.T) {
+	t.Parallel()
+
+	w := NewWriter(hijackWriter{httptest.NewRecorder()})
+	_, _, err := http.NewResponseController(w).Hijack()
+	assert.ErrorIs(t, err, errHijacked)
+
+	_, _, err = http.NewResponseController(NewWriter(httptest.NewRecorder())).Hijack()
+	assert.ErrorIs(t, err, http.ErrNotSupported)
+}
+
+func TestWriterMiddleware_Hijack(t *testing.T) {
+	t.Parallel()
+
+	wr := httptest.NewRecorder()
+	handler := WriterMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, Add(w, Retarget("#foo"), Trigger(Event("a"))))
+		assert.NoError(t, Add(w, Trigger(Event("a"))))
+		_, _, err := http.NewResponseController(w).Hijack()
+		assert.NoError(t, err)
+		assert.ErrorIs(t, Add(w, Reswap(SwapOuterHtml)), ErrHeadersWritten)
+	}))
+
+	handler.ServeHTTP(hijackedWriter{wr}, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.False(t, wr.Flushed)
+	assert.Empty(t, wr.Body.String())
+	assert.Empty(t, wr.Header())
+}
+
+type wrappingWriter struct {
+	http.ResponseWriter
+}
+
+func (w wrappingWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
+
+var errHijacked = net.ErrClosed
+
+type hijackWriter struct {
+	http.ResponseWriter
+}
+
+func (hijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) { return nil, nil, errHijacked }
+
+// hijackedWriter is a writer whose connection is always hijacked successfully
+type hijackedWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (hijackedWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) { return nil, nil, nil }